@@ -2,21 +2,57 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	"github.com/Caryyon/antenna/internal/api"
+	"github.com/Caryyon/antenna/internal/config"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
 type App struct {
-	ctx    context.Context
-	client *api.Client
+	ctx context.Context
+
+	mu            sync.Mutex
+	loader        *config.Loader
+	config        config.Config
+	client        *api.Client
+	seenAnomalies map[string]bool
+	tracker       api.ChangeTracker
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
+	loader := &config.Loader{Path: config.DefaultPath()}
+	cfg, err := loader.Load()
+	if err != nil {
+		log.Printf("config: %v", err)
+	}
 	return &App{
-		client: api.NewClient(""),
+		loader:        loader,
+		config:        cfg,
+		client:        cfg.NewClient(),
+		seenAnomalies: make(map[string]bool),
+	}
+}
+
+// currentClient returns the client for the current config, reloading the
+// config file first if it has changed.
+func (a *App) currentClient() *api.Client {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.loader.Changed() {
+		cfg, err := a.loader.Load()
+		if err != nil {
+			log.Printf("config: %v", err)
+		}
+		a.config = cfg
+		a.client = cfg.NewClient()
 	}
+	return a.client
 }
 
 // startup is called when the app starts
@@ -30,15 +66,152 @@ type Session = api.Session
 // DashboardData is re-exported for Wails bindings
 type DashboardData = api.DashboardData
 
-// HourlyBucket is re-exported for Wails bindings
-type HourlyBucket = api.HourlyBucket
+// ActivityBucket is re-exported for Wails bindings
+type ActivityBucket = api.ActivityBucket
+
+// SessionTimeline is re-exported for Wails bindings
+type SessionTimeline = api.SessionTimeline
+
+// Annotation is re-exported for Wails bindings
+type Annotation = api.Annotation
+
+// Forecast is re-exported for Wails bindings
+type Forecast = api.Forecast
+
+// Heatmap is re-exported for Wails bindings
+type Heatmap = api.Heatmap
+
+// ActivityFilter is re-exported for Wails bindings
+type ActivityFilter = api.ActivityFilter
+
+// Anomaly is re-exported for Wails bindings
+type Anomaly = api.Anomaly
 
-// GetDashboard returns the dashboard data
-func (a *App) GetDashboard() DashboardData {
-	return a.client.GetDashboard()
+// AnomalyEvent is the payload of the "anomaly" event.
+type AnomalyEvent struct {
+	SessionID string  `json:"sessionId"`
+	Name      string  `json:"name"`
+	Anomaly   Anomaly `json:"anomaly"`
+}
+
+// SessionChange is re-exported for Wails bindings
+type SessionChange = api.SessionChange
+
+// DashboardDiff is re-exported for Wails bindings
+type DashboardDiff = api.DashboardDiff
+
+// DashboardUpdate is the dashboard data with how it changed since the
+// previous GetDashboard call.
+type DashboardUpdate struct {
+	DashboardData
+	Changes DashboardDiff `json:"changes"`
+}
+
+// GetDashboard returns the dashboard data and what changed since the last
+// call
+func (a *App) GetDashboard() DashboardUpdate {
+	data := a.currentClient().GetDashboard()
+	a.emitAnomalies(data.Sessions)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return DashboardUpdate{DashboardData: data, Changes: a.tracker.Track(data)}
+}
+
+// emitAnomalies sends an "anomaly" event for each anomaly not seen before.
+// Only the current anomalies are remembered, so the set stays small.
+func (a *App) emitAnomalies(sessions []Session) {
+	if a.ctx == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	seen := make(map[string]bool)
+	for _, s := range sessions {
+		for _, an := range s.Anomalies {
+			key := fmt.Sprintf("%s/%s/%d", s.SessionID, an.Kind, an.At)
+			seen[key] = true
+			if a.seenAnomalies[key] {
+				continue
+			}
+			runtime.EventsEmit(a.ctx, "anomaly", AnomalyEvent{SessionID: s.SessionID, Name: s.Name, Anomaly: an})
+		}
+	}
+	a.seenAnomalies = seen
+}
+
+// ErrorEvent is re-exported for Wails bindings
+type ErrorEvent = api.ErrorEvent
+
+// GetErrors returns error events across all sessions, newest first
+func (a *App) GetErrors() []ErrorEvent {
+	return a.currentClient().GetErrors()
+}
+
+// LatencyReport is re-exported for Wails bindings
+type LatencyReport = api.LatencyReport
+
+// GetLatency returns latency and throughput percentiles per model and session
+func (a *App) GetLatency() LatencyReport {
+	return a.currentClient().GetLatency()
+}
+
+// GetActivity returns message counts and costs between two Unix
+// millisecond times in clock-aligned buckets of "1m", "5m", "1h" or "1d",
+// in the configured time zone
+func (a *App) GetActivity(from, to int64, bucket string, filter ActivityFilter) ([]ActivityBucket, error) {
+	size, err := api.ParseBucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	client := a.currentClient()
+	return client.GetActivity(client.In(time.UnixMilli(from)), client.In(time.UnixMilli(to)), size, filter)
+}
+
+// GetSessionTimeline returns one session's message and cost buckets and
+// tool calls, bucketed automatically
+func (a *App) GetSessionTimeline(sessionID string) (*SessionTimeline, error) {
+	return a.currentClient().GetSessionTimeline(sessionID, 0)
+}
+
+// GetHeatmap returns messages and cost by weekday and hour over the last
+// days days, in the configured time zone
+func (a *App) GetHeatmap(days int) (*Heatmap, error) {
+	return a.currentClient().GetHeatmap(time.Duration(days)*24*time.Hour, ActivityFilter{})
+}
+
+// GetForecast returns projected spend for the day, week and month
+func (a *App) GetForecast() Forecast {
+	return a.currentClient().GetForecast()
+}
+
+// SetAnnotation saves the user's name, tags, note, pin and hidden flag for
+// a session
+func (a *App) SetAnnotation(sessionID string, annotation Annotation) error {
+	return a.currentClient().SetAnnotation(sessionID, annotation)
+}
+
+// CopySession puts a session on the clipboard as its ID, transcript path,
+// a summary line or Markdown; see api.CopyFormats
+func (a *App) CopySession(sessionID, format string) error {
+	client := a.currentClient()
+	for _, s := range client.GetDashboard().Sessions {
+		if s.SessionID != sessionID {
+			continue
+		}
+		text, err := client.SessionText(s, format)
+		if err != nil {
+			return err
+		}
+		return runtime.ClipboardSetText(a.ctx, text)
+	}
+	return fmt.Errorf("no session %s", sessionID)
 }
 
-// GetHourlyActivity returns message counts and costs bucketed by hour for the last 24h
-func (a *App) GetHourlyActivity() []HourlyBucket {
-	return a.client.GetHourlyActivity()
+// GetRefreshInterval returns the configured refresh interval in
+// milliseconds
+func (a *App) GetRefreshInterval() int64 {
+	a.currentClient()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return time.Duration(a.config.Interval).Milliseconds()
 }
@@ -0,0 +1,521 @@
+// Command antenna-fixtures writes a synthetic ~/.openclaw directory tree so
+// Antenna's parser and UIs can be exercised without real transcripts.
+//
+//	go run ./cmd/antenna-fixtures -out /tmp/openclaw -seed 42
+//	OPENCLAW_DIR=/tmp/openclaw go run ./cmd/antenna-tui
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type options struct {
+	out       string
+	seed      int64
+	now       time.Time
+	agents    []string
+	mains     int
+	crons     int
+	subagents int
+	messages  int
+	models    []string
+	costDist  string
+	costMean  float64
+	spread    time.Duration
+	active    int
+	stuck     int
+	errors    float64
+	malformed float64
+}
+
+// --- OpenClaw JSON shapes (the subset Antenna reads) ---
+
+type sessionEntry struct {
+	SessionID   string `json:"sessionId"`
+	UpdatedAt   int64  `json:"updatedAt"`
+	Label       string `json:"label,omitempty"`
+	Model       string `json:"model,omitempty"`
+	TotalTokens int    `json:"totalTokens"`
+	SpawnedBy   string `json:"spawnedBy,omitempty"`
+}
+
+type cronJobsFile struct {
+	Version int       `json:"version"`
+	Jobs    []cronJob `json:"jobs"`
+}
+
+type cronJob struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Enabled  bool         `json:"enabled"`
+	Schedule cronSchedule `json:"schedule"`
+}
+
+type cronSchedule struct {
+	Kind string `json:"kind"`
+	Expr string `json:"expr"`
+}
+
+type generator struct {
+	opts options
+	rng  *rand.Rand
+}
+
+var cronNames = []string{
+	"heartbeat", "inbox-triage", "daily-digest", "repo-watch", "calendar-sync",
+	"news-brief", "backup-check", "standup-notes", "invoice-scan", "weather",
+}
+
+var labels = []string{
+	"refactor parser", "write release notes", "debug flaky test", "plan trip",
+	"research vector dbs", "fix ci", "summarize thread", "draft blog post",
+	"review PR", "migrate config",
+}
+
+var providerErrors = []string{
+	`429 {"type":"error","error":{"type":"rate_limit_error","message":"Rate limit exceeded"}}`,
+	`529 {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`,
+	`500 {"type":"error","error":{"type":"api_error","message":"Internal server error"}}`,
+	"Request was aborted",
+}
+
+var tools = []string{"exec", "read", "write", "edit", "web_search", "web_fetch", "browser"}
+
+func main() {
+	opts, err := parseFlags(os.Args[1:], os.Stderr)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		os.Exit(2) // parseFlags printed the error and usage
+	}
+	g := &generator{opts: opts, rng: rand.New(rand.NewSource(opts.seed))}
+	n, err := g.run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote %d sessions to %s\n", n, opts.out)
+}
+
+// parseFlags parses and checks the command line, printing any error and
+// the usage to stderr.
+func parseFlags(args []string, stderr io.Writer) (options, error) {
+	fs := flag.NewFlagSet("antenna-fixtures", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var o options
+	var agents, models, now string
+	fs.StringVar(&o.out, "out", "openclaw-fixtures", "output directory (acts as OPENCLAW_DIR)")
+	fs.Int64Var(&o.seed, "seed", 1, "random seed; same seed and -now give identical output")
+	fs.StringVar(&now, "now", "", "reference time (RFC 3339); defaults to the current time")
+	fs.StringVar(&agents, "agents", "main", "comma-separated agent names")
+	fs.IntVar(&o.mains, "main", 6, "main sessions per agent")
+	fs.IntVar(&o.crons, "cron", 3, "cron jobs per agent")
+	fs.IntVar(&o.subagents, "subagents", 4, "sub-agent sessions per agent")
+	fs.IntVar(&o.messages, "messages", 30, "mean messages per session")
+	fs.StringVar(&models, "models", "anthropic/claude-opus-4-5,anthropic/claude-sonnet-4-5,openai/gpt-5", "comma-separated models to draw from")
+	fs.StringVar(&o.costDist, "cost-dist", "lognormal", "per-message cost distribution: fixed, uniform, lognormal")
+	fs.Float64Var(&o.costMean, "cost-mean", 0.02, "mean cost in dollars per assistant message")
+	fs.DurationVar(&o.spread, "spread", 72*time.Hour, "how far back session activity is spread")
+	fs.IntVar(&o.active, "active", 2, "sessions per agent whose last message is within the last few minutes")
+	fs.IntVar(&o.stuck, "stuck", 1, "sub-agents per agent left waiting on an unanswered tool call")
+	fs.Float64Var(&o.errors, "errors", 0.02, "fraction of assistant turns that fail with a provider error (0-1)")
+	fs.Float64Var(&o.malformed, "malformed", 0, "fraction of transcript lines replaced with malformed JSON (0-1)")
+	if err := fs.Parse(args); err != nil {
+		return o, err // already printed with the usage
+	}
+	if err := o.set(agents, models, now); err != nil {
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		return o, err
+	}
+	return o, nil
+}
+
+// set fills in the options parsed from strings and checks the rest.
+func (o *options) set(agents, models, now string) error {
+	o.agents = splitList(agents)
+	o.models = splitList(models)
+	if len(o.agents) == 0 {
+		return fmt.Errorf("-agents must name at least one agent")
+	}
+	if len(o.models) == 0 {
+		return fmt.Errorf("-models must name at least one model")
+	}
+	for _, n := range []struct {
+		flag  string
+		value int
+	}{
+		{"-main", o.mains}, {"-cron", o.crons}, {"-subagents", o.subagents},
+		{"-active", o.active}, {"-stuck", o.stuck},
+	} {
+		if n.value < 0 {
+			return fmt.Errorf("%s must not be negative", n.flag)
+		}
+	}
+	if o.messages < 1 {
+		return fmt.Errorf("-messages must be at least 1")
+	}
+	switch o.costDist {
+	case "fixed", "uniform", "lognormal":
+	default:
+		return fmt.Errorf("unknown -cost-dist %q", o.costDist)
+	}
+	// Written so NaN fails too.
+	if !(o.costMean >= 0) || math.IsInf(o.costMean, 0) {
+		return fmt.Errorf("-cost-mean must be a non-negative number")
+	}
+	if !(o.malformed >= 0 && o.malformed <= 1) {
+		return fmt.Errorf("-malformed must be between 0 and 1")
+	}
+	if !(o.errors >= 0 && o.errors <= 1) {
+		return fmt.Errorf("-errors must be between 0 and 1")
+	}
+	if o.spread <= 0 {
+		return fmt.Errorf("-spread must be positive")
+	}
+	o.now = time.Now()
+	if now != "" {
+		t, err := time.Parse(time.RFC3339, now)
+		if err != nil {
+			return fmt.Errorf("-now: %w", err)
+		}
+		o.now = t
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// run writes the whole tree and returns the number of sessions written.
+func (g *generator) run() (int, error) {
+	total := 0
+	var jobs []cronJob
+	for _, agent := range g.opts.agents {
+		n, agentJobs, err := g.writeAgent(agent)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		jobs = append(jobs, agentJobs...)
+	}
+
+	cronDir := filepath.Join(g.opts.out, "cron")
+	if err := os.MkdirAll(cronDir, 0o755); err != nil {
+		return total, err
+	}
+	if err := writeJSON(filepath.Join(cronDir, "jobs.json"), cronJobsFile{Version: 1, Jobs: jobs}); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+func (g *generator) writeAgent(agent string) (int, []cronJob, error) {
+	dir := filepath.Join(g.opts.out, "agents", agent, "sessions")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, nil, err
+	}
+
+	meta := make(map[string]sessionEntry)
+	var jobs []cronJob
+	var mainKeys []string
+	index := 0
+
+	add := func(key, label string, hang bool) error {
+		id := g.uuid()
+		end := g.endTime(index)
+		index++
+		mdl := g.opts.models[g.rng.Intn(len(g.opts.models))]
+		tokens, err := g.writeTranscript(filepath.Join(dir, id+".jsonl"), id, mdl, end, hang)
+		if err != nil {
+			return err
+		}
+		meta[key] = sessionEntry{
+			SessionID:   id,
+			UpdatedAt:   end.UnixMilli(),
+			Label:       label,
+			Model:       mdl,
+			TotalTokens: tokens,
+		}
+		return nil
+	}
+
+	for i := 0; i < g.opts.mains; i++ {
+		key := fmt.Sprintf("agent:%s:main", agent)
+		if i > 0 {
+			key = fmt.Sprintf("agent:%s:telegram:dm:%d", agent, 1000+i)
+		}
+		label := ""
+		if g.rng.Float64() < 0.5 {
+			label = labels[g.rng.Intn(len(labels))]
+		}
+		if err := add(key, label, false); err != nil {
+			return 0, nil, err
+		}
+		mainKeys = append(mainKeys, key)
+	}
+
+	for i := 0; i < g.opts.crons; i++ {
+		job := cronJob{
+			ID:       g.uuid(),
+			Name:     cronNames[i%len(cronNames)],
+			Enabled:  true,
+			Schedule: cronSchedule{Kind: "cron", Expr: fmt.Sprintf("%d * * * *", g.rng.Intn(60))},
+		}
+		jobs = append(jobs, job)
+		if err := add(fmt.Sprintf("agent:%s:cron:%s", agent, job.ID), "", false); err != nil {
+			return 0, nil, err
+		}
+		// A second run keyed by run ID, as OpenClaw records them.
+		runKey := fmt.Sprintf("agent:%s:cron:%s:run:%s", agent, job.ID, g.uuid())
+		if err := add(runKey, "", false); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	for i := 0; i < g.opts.subagents; i++ {
+		key := fmt.Sprintf("agent:%s:subagent:%s", agent, g.uuid())
+		label := ""
+		if g.rng.Float64() < 0.7 {
+			label = labels[g.rng.Intn(len(labels))]
+		}
+		if err := add(key, label, i < g.opts.stuck); err != nil {
+			return 0, nil, err
+		}
+		if len(mainKeys) > 0 {
+			e := meta[key]
+			e.SpawnedBy = mainKeys[g.rng.Intn(len(mainKeys))]
+			meta[key] = e
+		}
+	}
+
+	if err := writeJSON(filepath.Join(dir, "sessions.json"), meta); err != nil {
+		return 0, nil, err
+	}
+	return len(meta), jobs, nil
+}
+
+// endTime picks when a session's last message happened. The first
+// opts.active sessions of each agent end within the last few minutes.
+func (g *generator) endTime(index int) time.Time {
+	if index < g.opts.active {
+		return g.opts.now.Add(-time.Duration(g.rng.Int63n(int64(5 * time.Minute))))
+	}
+	return g.opts.now.Add(-time.Duration(g.rng.Int63n(int64(g.opts.spread))))
+}
+
+// writeTranscript writes one session transcript ending at end and returns
+// the total tokens used, for sessions.json. Transcripts end with a finished
+// assistant reply, or with an unanswered tool call when hang is set.
+func (g *generator) writeTranscript(path, id, mdl string, end time.Time, hang bool) (int, error) {
+	count := g.messageCount()
+	provider, _, _ := strings.Cut(mdl, "/")
+
+	// Build the messages with offsets from the start, 5-90s apart, then
+	// shift them so the last one lands on end.
+	type draft struct {
+		at  time.Duration
+		msg map[string]any
+	}
+	var drafts []draft
+	var at time.Duration
+	tokens := 0
+	next := "user"
+	pendingTool := ""
+	pendingName := ""
+	for i := 0; ; i++ {
+		// From the count-th message on, stop at the first assistant turn.
+		done := i >= count-1
+		at += time.Duration(5+g.rng.Intn(85)) * time.Second
+		var msg map[string]any
+		switch next {
+		case "toolResult":
+			failed := g.rng.Float64() < 0.05
+			text := "ok"
+			if failed {
+				text = "Error: command exited with code 1"
+			}
+			msg = map[string]any{
+				"role":       "toolResult",
+				"toolCallId": pendingTool,
+				"toolName":   pendingName,
+				"content":    []map[string]any{{"type": "text", "text": text}},
+				"isError":    failed,
+			}
+			next = "assistant"
+		case "user":
+			msg = map[string]any{
+				"role":    "user",
+				"content": []map[string]any{{"type": "text", "text": labels[g.rng.Intn(len(labels))]}},
+			}
+			next = "assistant"
+		default:
+			input := 500 + g.rng.Intn(20000)
+			output := 50 + g.rng.Intn(2000)
+			tokens += input + output
+			content := []map[string]any{{"type": "text", "text": "Working on it."}}
+			stop := "stop"
+			withTool := g.rng.Float64() < 0.4
+			if done {
+				withTool = hang
+			}
+			next = "user"
+			if withTool {
+				pendingTool = "call_" + g.uuid()[:8]
+				pendingName = tools[g.rng.Intn(len(tools))]
+				content = append(content, map[string]any{
+					"type":      "toolCall",
+					"id":        pendingTool,
+					"name":      pendingName,
+					"arguments": map[string]any{"command": "ls -la"},
+				})
+				stop = "toolUse"
+				next = "toolResult"
+			}
+			msg = map[string]any{
+				"role":       "assistant",
+				"content":    content,
+				"provider":   provider,
+				"model":      strings.TrimPrefix(mdl, provider+"/"),
+				"stopReason": stop,
+				"usage": map[string]any{
+					"input":       input,
+					"output":      output,
+					"totalTokens": input + output,
+					"cost":        map[string]any{"total": g.cost()},
+				},
+			}
+			if !done && !withTool && g.rng.Float64() < g.opts.errors {
+				errText := providerErrors[g.rng.Intn(len(providerErrors))]
+				msg["content"] = []map[string]any{}
+				msg["stopReason"] = "error"
+				if strings.Contains(errText, "aborted") {
+					msg["stopReason"] = "aborted"
+				}
+				msg["errorMessage"] = errText
+			}
+		}
+		drafts = append(drafts, draft{at: at, msg: msg})
+		if done && msg["role"] == "assistant" {
+			break
+		}
+	}
+	start := end.Add(-at)
+
+	var lines []string
+	add := func(v any) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		lines = append(lines, string(data))
+		return nil
+	}
+
+	if err := add(map[string]any{
+		"type":      "session",
+		"version":   3,
+		"id":        id,
+		"timestamp": start.UTC().Format(time.RFC3339Nano),
+		"cwd":       "/home/agent/workspace",
+	}); err != nil {
+		return 0, err
+	}
+	for _, d := range drafts {
+		t := start.Add(d.at)
+		d.msg["timestamp"] = t.UnixMilli()
+		if err := add(map[string]any{
+			"type":      "message",
+			"id":        fmt.Sprintf("%08x", g.rng.Uint32()),
+			"timestamp": t.UTC().Format(time.RFC3339Nano),
+			"message":   d.msg,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	for i := range lines {
+		if i > 0 && g.rng.Float64() < g.opts.malformed {
+			lines[i] = g.corrupt(lines[i])
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return 0, err
+	}
+	// Antenna falls back to the file's mtime when sessions.json has no entry.
+	return tokens, os.Chtimes(path, end, end)
+}
+
+func (g *generator) messageCount() int {
+	n := int(float64(g.opts.messages) * (0.25 + 1.5*g.rng.Float64()))
+	if n < 2 {
+		n = 2
+	}
+	return n
+}
+
+func (g *generator) cost() float64 {
+	mean := g.opts.costMean
+	var c float64
+	switch g.opts.costDist {
+	case "fixed":
+		c = mean
+	case "uniform":
+		c = 2 * mean * g.rng.Float64()
+	default:
+		// Lognormal with sigma 1, scaled so the mean matches.
+		const sigma = 1.0
+		mu := math.Log(mean) - sigma*sigma/2
+		c = math.Exp(mu + sigma*g.rng.NormFloat64())
+	}
+	return math.Round(c*1e6) / 1e6
+}
+
+// corrupt returns a broken version of a JSON line, in one of the ways real
+// transcripts break: truncated writes, garbage, or wrongly typed fields.
+func (g *generator) corrupt(line string) string {
+	switch g.rng.Intn(4) {
+	case 0:
+		return line[:len(line)/2]
+	case 1:
+		return "not json at all"
+	case 2:
+		return `{"type":"message","message":"oops"}`
+	default:
+		return ""
+	}
+}
+
+func (g *generator) uuid() string {
+	b := make([]byte, 16)
+	g.rng.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/Caryyon/antenna/internal/api"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"-main", "0", "-cron", "0", "-subagents", "0"}, false},
+		{[]string{"-cost-mean", "0", "-cost-dist", "fixed"}, false},
+		{[]string{"-main", "-1"}, true},
+		{[]string{"-cron", "-1"}, true},
+		{[]string{"-subagents", "-2"}, true},
+		{[]string{"-messages", "-5"}, true},
+		{[]string{"-messages", "0"}, true},
+		{[]string{"-cost-mean", "-0.01"}, true},
+		{[]string{"-cost-mean", "NaN"}, true},
+		{[]string{"-cost-mean", "+Inf"}, true},
+		{[]string{"-errors", "NaN"}, true},
+		{[]string{"-malformed", "2"}, true},
+		{[]string{"-cost-dist", "pareto"}, true},
+		{[]string{"-agents", ","}, true},
+		{[]string{"-now", "yesterday"}, true},
+	}
+	for _, tt := range tests {
+		if _, err := parseFlags(tt.args, io.Discard); (err != nil) != tt.wantErr {
+			t.Errorf("parseFlags(%q) error = %v, want error %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestGenerateLoads(t *testing.T) {
+	dir := t.TempDir()
+	opts, err := parseFlags([]string{"-out", dir, "-seed", "7", "-agents", "main,ops", "-malformed", "0.05"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{opts: opts, rng: rand.New(rand.NewSource(opts.seed))}
+	n, err := g.run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 2 * (opts.mains + 2*opts.crons + opts.subagents); n != want {
+		t.Errorf("wrote %d sessions, want %d", n, want)
+	}
+
+	c := api.NewClient(dir)
+	c.AnnotationsPath = filepath.Join(dir, "annotations.json")
+	data, err := c.LoadDashboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Sessions) != n {
+		t.Fatalf("loaded %d sessions, want %d", len(data.Sessions), n)
+	}
+	kinds := make(map[string]int)
+	agents := make(map[string]int)
+	stuck := 0
+	for _, s := range data.Sessions {
+		kinds[s.Kind]++
+		agents[s.Agent]++
+		if s.Kind == "subagent" && s.ParentID == "" {
+			t.Errorf("sub-agent %s has no parent", s.SessionID)
+		}
+		if s.Stuck {
+			stuck++
+		}
+	}
+	if kinds["main"] != 2*opts.mains || kinds["cron"] != 4*opts.crons || kinds["subagent"] != 2*opts.subagents {
+		t.Errorf("kinds %v", kinds)
+	}
+	if agents["main"] != n/2 || agents["ops"] != n/2 {
+		t.Errorf("agents %v", agents)
+	}
+	// Sub-agents whose parent has since replied count as stuck too.
+	if stuck < 2*opts.stuck {
+		t.Errorf("%d stuck sub-agents, want at least %d", stuck, 2*opts.stuck)
+	}
+	if data.TotalCost <= 0 || data.TodayCost > data.TotalCost {
+		t.Errorf("total $%v, today $%v", data.TotalCost, data.TodayCost)
+	}
+}
+
+func TestGenerateReportsUnwritableCost(t *testing.T) {
+	// Uniform costs up to twice the mean overflow to +Inf, which JSON
+	// cannot encode.
+	opts, err := parseFlags([]string{"-out", t.TempDir(), "-cost-dist", "uniform", "-cost-mean", "1e308"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &generator{opts: opts, rng: rand.New(rand.NewSource(opts.seed))}
+	if _, err := g.run(); err == nil {
+		t.Error("no error for a cost JSON cannot encode")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Caryyon/antenna/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightFor is how long a changed session row stays highlighted.
+const highlightFor = 5 * time.Second
+
+// highlight is a session change shown until a deadline.
+type highlight struct {
+	api.SessionChange
+	until time.Time
+}
+
+// highlightExpiredMsg asks Update to drop highlights past their deadline.
+type highlightExpiredMsg struct{}
+
+// trackChanges applies how a load differs from the previous one: it
+// highlights the sessions that are new, became active or grew, and records
+// how the visible session count and cost moved for the stats bar. A
+// session that changes again while highlighted keeps its earlier deltas.
+func (m *model) trackChanges(diff api.DashboardDiff, prevTotals api.DashboardData, now time.Time) {
+	totals := m.totals()
+	m.sessionDelta = totals.TotalCount - prevTotals.TotalCount
+	m.costDelta = m.costCarry.Add(totals.TotalCost - prevTotals.TotalCost)
+
+	for id, h := range m.changes {
+		if !now.Before(h.until) {
+			delete(m.changes, id)
+		}
+	}
+	for _, c := range diff.Sessions {
+		if h, ok := m.changes[c.SessionID]; ok {
+			c = h.SessionChange.Merge(c)
+		}
+		if m.changes == nil {
+			m.changes = make(map[string]highlight)
+		}
+		m.changes[c.SessionID] = highlight{c, now.Add(highlightFor)}
+	}
+}
+
+// expireHighlights schedules a redraw for when the current highlights end.
+func (m model) expireHighlights() tea.Cmd {
+	if len(m.changes) == 0 {
+		return nil
+	}
+	return tea.Tick(highlightFor, func(time.Time) tea.Msg { return highlightExpiredMsg{} })
+}
+
+// changeFor returns the highlighted change of s, if any.
+func (m model) changeFor(s api.Session) (api.SessionChange, bool) {
+	h, ok := m.changes[s.SessionID]
+	if !ok || !time.Now().Before(h.until) {
+		return api.SessionChange{}, false
+	}
+	return h.SessionChange, true
+}
+
+// badges returns the row badges of s, led by what changed in it.
+func (m model) badges(s api.Session) string {
+	badges := m.theme.badges(s)
+	c, ok := m.changeFor(s)
+	if !ok {
+		return badges
+	}
+	var parts []string
+	switch {
+	case c.New:
+		parts = append(parts, "new")
+	case c.BecameActive:
+		parts = append(parts, "▲ active")
+	}
+	switch {
+	case c.CostDelta > 0:
+		parts = append(parts, fmt.Sprintf("+$%.2f", c.CostDelta))
+	case c.MessageDelta > 0:
+		parts = append(parts, fmt.Sprintf("+%d msgs", c.MessageDelta))
+	}
+	if len(parts) == 0 {
+		return badges
+	}
+	change := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Cyan).Render(strings.Join(parts, " "))
+	if badges == "" {
+		return change
+	}
+	return change + "  " + badges
+}
+
+// highlighted draws a changed row with the changed style, w cells wide.
+func (m model) highlighted(s api.Session, line string, w int) string {
+	if _, ok := m.changeFor(s); !ok {
+		return line
+	}
+	return m.theme.changed().Render(padRight(line, w))
+}
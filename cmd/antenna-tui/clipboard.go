@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyLabels name each api.CopyFormats format in status messages.
+var copyLabels = map[string]string{
+	"id":       "session ID",
+	"path":     "transcript path",
+	"summary":  "summary",
+	"markdown": "session as Markdown",
+}
+
+// copySession copies the selected session to the clipboard in one of
+// api.CopyFormats.
+func (m *model) copySession(format string) tea.Cmd {
+	s, ok := m.selectedSession()
+	if !ok {
+		m.status = "no session selected"
+		return nil
+	}
+	text, err := m.client.SessionText(s, format)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	return copyText(m.out, text, copyLabels[format])
+}
+
+// copiedMsg reports whether a copy reached the terminal.
+type copiedMsg struct {
+	label string
+	err   error
+}
+
+// copyText sets the terminal clipboard with an OSC 52 sequence, which also
+// works over SSH, wrapped for tmux or screen when running inside them. It
+// is written to out, the program's own output.
+func copyText(out io.Writer, text, label string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		switch {
+		case os.Getenv("TMUX") != "":
+			seq = seq.Tmux()
+		case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+			seq = seq.Screen()
+		}
+		_, err := seq.WriteTo(out)
+		return copiedMsg{label, err}
+	}
+}
+
+// lockedFile is the program's output. Writes are serialized so an OSC 52
+// sequence never lands in the middle of a frame the renderer is writing.
+type lockedFile struct {
+	*os.File
+	mu sync.Mutex
+}
+
+func (f *lockedFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.File.Write(p)
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// binding is one entry of the keymap registry: an action, the keys that
+// trigger it by default and where it applies. Update dispatches on action
+// names, and the help overlay and footers are generated from the registry.
+type binding struct {
+	action string   // name used in the config file's "keys" object and by Update
+	keys   []string // default keys, as tea.KeyMsg.String() spells them
+	views  []view   // views the action applies to; nil means every view
+	group  string   // help overlay section
+	help   string   // help overlay description
+	footer string   // footer label; actions next to each other with the same label share it
+	hints  []view   // views whose footer shows the action; nil means views
+}
+
+var allViews = []view{viewDashboard, viewDetail, viewErrors, viewLatency, viewHeatmap, viewTail}
+
+// Views that share bindings.
+var (
+	scrollViews = []view{viewDashboard, viewErrors, viewTail}
+	subViews    = []view{viewDetail, viewErrors, viewLatency, viewHeatmap, viewTail}
+	loadViews   = []view{viewDashboard, viewDetail, viewErrors, viewLatency, viewHeatmap}
+	annotViews  = []view{viewDashboard, viewDetail}
+	openViews   = []view{viewDashboard, viewDetail, viewTail}
+)
+
+// bindings is the default keymap, vim-style, in help and footer order.
+var bindings = []binding{
+	{action: "down", keys: []string{"j", "down"}, views: scrollViews, group: "Navigation", help: "Move down, or scroll", footer: "move"},
+	{action: "up", keys: []string{"k", "up"}, views: scrollViews, group: "Navigation", help: "Move up, or scroll", footer: "move"},
+	{action: "pageUp", keys: []string{"pgup"}, views: scrollViews, group: "Navigation", help: "Page up", footer: "page"},
+	{action: "pageDown", keys: []string{"pgdown"}, views: scrollViews, group: "Navigation", help: "Page down", footer: "page"},
+	{action: "top", keys: []string{"home", "g"}, views: scrollViews, group: "Navigation", help: "Jump to the first row"},
+	{action: "bottom", keys: []string{"end", "G"}, views: scrollViews, group: "Navigation", help: "Jump to the last row; resumes following"},
+	// left has no ctrl+h: many terminals send it for backspace.
+	{action: "left", keys: []string{"h"}, views: []view{viewDashboard}, group: "Navigation", help: "Focus the left column", footer: "column"},
+	{action: "right", keys: []string{"l", "ctrl+l"}, views: []view{viewDashboard}, group: "Navigation", help: "Focus the right column", footer: "column"},
+	{action: "sectionDown", keys: []string{"ctrl+j"}, views: []view{viewDashboard}, group: "Navigation", help: "Focus the section below", footer: "section"},
+	{action: "sectionUp", keys: []string{"ctrl+k"}, views: []view{viewDashboard}, group: "Navigation", help: "Focus the section above", footer: "section"},
+	{action: "open", keys: []string{"enter"}, views: []view{viewDashboard}, group: "Navigation", help: "Open the selected session", footer: "detail"},
+	{action: "nextSection", keys: []string{"tab"}, views: []view{viewDashboard}, group: "Navigation", help: "Cycle through the sections", footer: "cycle"},
+	{action: "back", keys: []string{"esc", "backspace"}, views: subViews, group: "Navigation", help: "Go back", footer: "back"},
+
+	{action: "errors", keys: []string{"e"}, views: []view{viewDashboard}, group: "Views", help: "Errors across all sessions", footer: "errors"},
+	{action: "latency", keys: []string{"L"}, views: []view{viewDashboard}, group: "Views", help: "Latency by model", footer: "latency"},
+	{action: "heatmap", keys: []string{"H"}, views: []view{viewDashboard}, group: "Views", help: "Weekday × hour heatmap", footer: "heatmap"},
+	{action: "heatmapPrev", keys: []string{"["}, views: []view{viewHeatmap}, group: "Views", help: "Heatmap: previous range", footer: "range"},
+	{action: "heatmapNext", keys: []string{"]"}, views: []view{viewHeatmap}, group: "Views", help: "Heatmap: next range", footer: "range"},
+	{action: "heatmapCost", keys: []string{"c"}, views: []view{viewHeatmap}, group: "Views", help: "Heatmap: messages or cost", footer: "messages/cost"},
+	{action: "follow", keys: []string{"f"}, views: []view{viewDetail, viewTail}, group: "Views", help: "Follow the session live, or stop", footer: "follow", hints: []view{viewDetail}},
+	{action: "pause", keys: []string{" "}, views: []view{viewTail}, group: "Views", help: "Follow: pause or resume", footer: "pause"},
+
+	{action: "filter", keys: []string{"/"}, group: "Sessions", help: "Filter sessions, errors and the stats bar", footer: "filter", hints: []view{viewDashboard}},
+	{action: "tagFilter", keys: []string{"#"}, group: "Sessions", help: "Set the filter's tag: term"},
+	{action: "showHidden", keys: []string{"X"}, group: "Sessions", help: "Show or hide hidden sessions"},
+	{action: "sort", keys: []string{"s"}, views: []view{viewDashboard}, group: "Sessions", help: "Cycle the sort order", footer: "sort"},
+	{action: "sortReverse", keys: []string{"S"}, views: []view{viewDashboard}, group: "Sessions", help: "Reverse the sort order", footer: "sort"},
+	{action: "columns", keys: []string{"C"}, views: []view{viewDashboard}, group: "Sessions", help: "Choose the session row columns", footer: "columns"},
+	{action: "rename", keys: []string{"n"}, views: annotViews, group: "Sessions", help: "Rename the session", footer: "rename", hints: []view{viewDetail}},
+	{action: "tags", keys: []string{"t"}, views: annotViews, group: "Sessions", help: "Edit the session's tags", footer: "tags", hints: []view{viewDetail}},
+	{action: "note", keys: []string{"a"}, views: annotViews, group: "Sessions", help: "Edit the session's note", footer: "note", hints: []view{viewDetail}},
+	{action: "pin", keys: []string{"p"}, views: annotViews, group: "Sessions", help: "Pin or unpin the session", footer: "pin", hints: []view{viewDetail}},
+	{action: "hide", keys: []string{"x"}, views: annotViews, group: "Sessions", help: "Hide or unhide the session", footer: "hide", hints: []view{viewDetail}},
+	{action: "pager", keys: []string{"o"}, views: openViews, group: "Sessions", help: "Read the transcript in $PAGER", footer: "pager", hints: []view{viewDetail}},
+	{action: "pagerRaw", keys: []string{"O"}, views: openViews, group: "Sessions", help: "Read the raw .jsonl transcript in $PAGER", footer: "pager", hints: []view{viewDetail}},
+	{action: "editor", keys: []string{"E"}, views: openViews, group: "Sessions", help: "Open the transcript as Markdown in $EDITOR"},
+	{action: "copyID", keys: []string{"y"}, views: annotViews, group: "Sessions", help: "Copy the session ID", footer: "copy", hints: []view{viewDetail}},
+	{action: "copyPath", keys: []string{"Y"}, views: annotViews, group: "Sessions", help: "Copy the transcript path", footer: "copy", hints: []view{viewDetail}},
+	{action: "copySummary", keys: []string{"ctrl+y"}, views: annotViews, group: "Sessions", help: "Copy a one-line summary", footer: "copy", hints: []view{viewDetail}},
+	{action: "copyMarkdown", keys: []string{"M"}, views: annotViews, group: "Sessions", help: "Copy the detail card as Markdown", footer: "copy", hints: []view{viewDetail}},
+
+	{action: "refresh", keys: []string{"r"}, group: "General", help: "Refresh now", footer: "refresh", hints: loadViews},
+	{action: "palette", keys: []string{":", "ctrl+p"}, group: "General", help: "Command palette", footer: "commands", hints: []view{viewDashboard}},
+	{action: "help", keys: []string{"?"}, group: "General", help: "Show this help", footer: "help"},
+	{action: "quit", keys: []string{"q", "ctrl+c"}, group: "General", help: "Quit, or leave the current view", footer: "quit"},
+}
+
+// keyMap resolves keys to actions per view after applying the config's
+// overrides.
+type keyMap struct {
+	keys    map[string][]string        // action → keys
+	actions map[view]map[string]string // view → key → action
+}
+
+// newKeyMap builds the keymap from the defaults and overrides, which give
+// an action's complete key list; an overridden action no longer answers to
+// its default keys. Where two actions end up sharing a key in a view the
+// overridden one wins and the clash is reported.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	km := keyMap{keys: make(map[string][]string), actions: make(map[view]map[string]string)}
+	known := make(map[string]bool)
+	for _, b := range bindings {
+		known[b.action] = true
+		km.keys[b.action] = b.keys
+	}
+	var unknown []string
+	for action, keys := range overrides {
+		if !known[action] {
+			unknown = append(unknown, action)
+			continue
+		}
+		km.keys[action] = nil
+		for _, k := range keys {
+			if k == "space" {
+				k = " "
+			}
+			km.keys[action] = append(km.keys[action], k)
+		}
+	}
+
+	conflicts := make(map[string]bool)
+	for _, v := range allViews {
+		km.actions[v] = make(map[string]string)
+	}
+	for _, b := range bindings {
+		for _, v := range b.scope() {
+			for _, k := range km.keys[b.action] {
+				prev, taken := km.actions[v][k]
+				if taken && prev != b.action {
+					conflicts[fmt.Sprintf("%s is bound to both %s and %s", keyLabel(k), prev, b.action)] = true
+					if _, mine := overrides[b.action]; !mine {
+						continue
+					}
+				}
+				km.actions[v][k] = b.action
+			}
+		}
+	}
+
+	var errs []string
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		errs = append(errs, "unknown key actions: "+strings.Join(unknown, ", "))
+	}
+	if len(conflicts) > 0 {
+		var list []string
+		for c := range conflicts {
+			list = append(list, c)
+		}
+		sort.Strings(list)
+		errs = append(errs, "key conflicts: "+strings.Join(list, "; "))
+	}
+	if len(errs) > 0 {
+		return km, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return km, nil
+}
+
+// action returns the action key triggers in v, or "".
+func (km keyMap) action(key string, v view) string {
+	return km.actions[v][key]
+}
+
+func (b binding) scope() []view {
+	if b.views == nil {
+		return allViews
+	}
+	return b.views
+}
+
+// in reports whether the action applies in v.
+func (b binding) in(v view) bool {
+	for _, s := range b.scope() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (b binding) hinted(v view) bool {
+	hints := b.hints
+	if hints == nil {
+		hints = b.scope()
+	}
+	for _, h := range hints {
+		if h == v {
+			return true
+		}
+	}
+	return false
+}
+
+// keyLabels spells keys for display.
+var keyLabels = map[string]string{
+	" ":         "space",
+	"up":        "↑",
+	"down":      "↓",
+	"pgdown":    "pgdn",
+	"backspace": "bksp",
+}
+
+func keyLabel(k string) string {
+	if l, ok := keyLabels[k]; ok {
+		return l
+	}
+	return k
+}
+
+// renderFooter lists the key hints for v from the keymap, dropping hints
+// before help and quit until the line fits in w.
+func (m model) renderFooter(v view, w int) string {
+	if m.snapshot {
+		return ""
+	}
+	type hint struct{ keys, label string }
+	var hints []hint
+	for _, b := range bindings {
+		keys := m.keys.keys[b.action]
+		if b.footer == "" || len(keys) == 0 || !b.hinted(v) {
+			continue
+		}
+		if n := len(hints); n > 0 && hints[n-1].label == b.footer {
+			hints[n-1].keys += "/" + keyLabel(keys[0])
+			continue
+		}
+		hints = append(hints, hint{keyLabel(keys[0]), b.footer})
+	}
+
+	footerDim := lipgloss.NewStyle().Foreground(m.theme.Dimmer)
+	footerKey := lipgloss.NewStyle().Foreground(m.theme.Dim)
+	render := func() string {
+		parts := make([]string, len(hints))
+		for i, h := range hints {
+			parts[i] = footerKey.Render(h.keys) + footerDim.Render(" "+h.label)
+		}
+		return footerDim.Render(" ") + strings.Join(parts, footerDim.Render("  "))
+	}
+	line := render()
+	for lipgloss.Width(line) > w && len(hints) > 2 {
+		hints = append(hints[:len(hints)-3], hints[len(hints)-2:]...)
+		line = render()
+	}
+	return line
+}
+
+// renderHelp draws the help overlay: every action with its keys, by
+// group, in two columns when there is room.
+func (m model) renderHelp(w int) string {
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Dim).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(m.theme.Green)
+	descStyle := lipgloss.NewStyle().Foreground(m.theme.Fg)
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.Dim)
+
+	const keyW = 16
+	var lines []string
+	group := ""
+	for _, b := range bindings {
+		if b.group != group {
+			if group != "" {
+				lines = append(lines, "")
+			}
+			group = b.group
+			lines = append(lines, headerStyle.Render("  ▌ "+strings.ToUpper(group)))
+		}
+		keys := make([]string, len(m.keys.keys[b.action]))
+		for i, k := range m.keys.keys[b.action] {
+			keys[i] = keyLabel(k)
+		}
+		label := strings.Join(keys, " ")
+		if label == "" {
+			label = "unbound"
+		}
+		lines = append(lines, "    "+keyStyle.Render(padRight(truncate(label, keyW), keyW))+descStyle.Render(b.help))
+	}
+
+	var b strings.Builder
+	if colW := w / 2; colW >= 60 {
+		// Break at the first group boundary past the middle.
+		left, right := lines, []string(nil)
+		for i := len(lines) / 2; i < len(lines); i++ {
+			if lines[i] == "" {
+				left, right = lines[:i], lines[i+1:]
+				break
+			}
+		}
+		for i := 0; i < maxInt(len(left), len(right)); i++ {
+			l, r := "", ""
+			if i < len(left) {
+				l = left[i]
+			}
+			if i < len(right) {
+				r = right[i]
+			}
+			b.WriteString(padRight(l, colW) + r + "\n")
+		}
+	} else {
+		for _, l := range lines {
+			b.WriteString(l + "\n")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("  Rebind keys with \"keys\" in the config file.  Any key closes this help."))
+	return b.String()
+}
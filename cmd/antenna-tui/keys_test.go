@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewKeyMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		key       string
+		view      view
+		action    string // what key triggers in view
+		wantErr   []string
+	}{
+		{name: "defaults", key: "j", view: viewDashboard, action: "down"},
+		{name: "default out of scope", key: "j", view: viewDetail, action: ""},
+		{name: "backspace byte unbound", key: "ctrl+h", view: viewDashboard, action: ""},
+		{
+			name:      "override replaces defaults",
+			overrides: map[string][]string{"down": {"J"}},
+			key:       "j", view: viewDashboard, action: "",
+		},
+		{
+			name:      "space spelled out",
+			overrides: map[string][]string{"pause": {"space", "P"}},
+			key:       " ", view: viewTail, action: "pause",
+		},
+		{
+			name:      "override wins a conflict",
+			overrides: map[string][]string{"sort": {"r"}},
+			key:       "r", view: viewDashboard, action: "sort",
+			wantErr: []string{"key conflicts: r is bound to both"},
+		},
+		{
+			name:      "conflict outside the override's views",
+			overrides: map[string][]string{"sort": {"r"}},
+			key:       "r", view: viewDetail, action: "refresh",
+			wantErr: []string{"key conflicts"},
+		},
+		{
+			name:      "unknown actions listed",
+			overrides: map[string][]string{"launch": {"L"}, "blink": {"b"}},
+			key:       "j", view: viewDashboard, action: "down",
+			wantErr: []string{"unknown key actions: blink, launch"},
+		},
+		{
+			name:      "both reported",
+			overrides: map[string][]string{"launch": {"L"}, "quit": {"j"}},
+			key:       "j", view: viewDashboard, action: "quit",
+			wantErr: []string{"unknown key actions: launch", "j is bound to both down and quit"},
+		},
+	}
+	for _, tt := range tests {
+		km, err := newKeyMap(tt.overrides)
+		if len(tt.wantErr) == 0 && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		for _, want := range tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: error %v, want it to mention %q", tt.name, err, want)
+			}
+		}
+		if got := km.action(tt.key, tt.view); got != tt.action {
+			t.Errorf("%s: %q in view %d = %q, want %q", tt.name, tt.key, tt.view, got, tt.action)
+		}
+	}
+}
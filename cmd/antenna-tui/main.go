@@ -1,44 +1,37 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Caryyon/antenna/internal/api"
+	"github.com/Caryyon/antenna/internal/config"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Gmork theme colors (matching web frontend CSS variables)
-var (
-	colorVoid    = lipgloss.Color("#050505")
-	colorPanel   = lipgloss.Color("#0a0a0a")
-	colorSurface = lipgloss.Color("#111111")
-	colorBorder  = lipgloss.Color("#1a1a1a")
-	colorGreen   = lipgloss.Color("#00ff99")
-	colorCyan    = lipgloss.Color("#00ffd5")
-	colorPurple  = lipgloss.Color("#bf6fff")
-	colorOrange  = lipgloss.Color("#ff8c4c")
-	colorRed     = lipgloss.Color("#ff4477")
-	colorDim     = lipgloss.Color("#555555")
-	colorDimmer  = lipgloss.Color("#333333")
-	colorFg      = lipgloss.Color("#bbbbbb")
-	colorWhite   = lipgloss.Color("#ffffff")
-
-	// Selection
-	colorSelectBg = lipgloss.Color("#0a2a1a")
-)
-
 type view int
 
 const (
 	viewDashboard view = iota
 	viewDetail
+	viewErrors
+	viewLatency
+	viewHeatmap
+	viewTail
 )
 
+// heatmapLookbacks are the ranges the heatmap view cycles through.
+var heatmapLookbacks = []time.Duration{7 * 24 * time.Hour, 14 * 24 * time.Hour, 30 * 24 * time.Hour, 90 * 24 * time.Hour}
+
 // Sections for navigation (matches web layout grid)
 const (
 	sectionActive = 0 // left top
@@ -49,22 +42,149 @@ const (
 
 type tickMsg time.Time
 
+// dashboardLoadedMsg carries the result of a background refresh. Besides
+// the dashboard it holds the data of the view that was open when the
+// refresh started; see refresh.
+type dashboardLoadedMsg struct {
+	seq int
+	at  time.Time
+	err error
+
+	dashboard api.DashboardData
+	activity  []api.ActivityBucket
+	forecast  api.Forecast
+
+	view       view
+	errors     []api.ErrorEvent
+	latency    api.LatencyReport
+	heatmap    *api.Heatmap
+	lookback   int    // heatmapLookback the heatmap was loaded for
+	timelineID string // session the timeline was loaded for
+	timeline   *api.SessionTimeline
+}
+
+// timelineLoadedMsg carries the timeline of a session opened in the
+// detail view.
+type timelineLoadedMsg struct {
+	id       string
+	timeline *api.SessionTimeline
+}
+
+// tailTickMsg polls the followed transcript. It carries the tailGen that
+// scheduled it so ticks from an earlier follow are dropped.
+type tailTickMsg int
+
+// tailInterval is how often follow mode checks for appended entries.
+const tailInterval = 500 * time.Millisecond
+
+// maxTailEntries bounds the entries follow mode keeps.
+const maxTailEntries = 2000
+
 type model struct {
 	client    *api.Client
+	loader    *config.Loader
+	keys      keyMap
+	theme     Theme
 	dashboard api.DashboardData
-	hourly    []api.HourlyBucket
+	forecast  api.Forecast
+	activity  []api.ActivityBucket
 	view      view
 	width     int
 	height    int
 	interval  time.Duration
-	err       error
+	err       error // why the last refresh failed; nil once one succeeds
+
+	loadSeq     int       // sequence number of the latest refresh started
+	appliedSeq  int       // sequence number of the latest refresh applied
+	lastRefresh time.Time // when the last applied refresh started
+	viewLoaded  bool      // the errors, latency or heatmap view has data since it was opened
+	reselectID  string    // session to select once refresh reselectSeq is applied
+	reselectSeq int
+
+	section    int              // focused section
+	sectionCur [4]int           // cursor per section
+	sectionOff [4]int           // first visible row per section
+	groups     [4][]api.Session // visible sessions per section, sorted; see regroup
+
+	allErrors    []api.ErrorEvent // every error event, newest first
+	errors       []api.ErrorEvent // allErrors of visible sessions
+	errorsOffset int              // first visible row of the errors view
+
+	latency api.LatencyReport
+
+	timeline *api.SessionTimeline // selected session's timeline in the detail view
+
+	heatmap         *api.Heatmap
+	heatmapLookback int  // index into heatmapLookbacks
+	heatmapCost     bool // shade by cost instead of messages
+
+	tail        *api.Tailer // follow mode on a session transcript
+	tailName    string
+	tailEntries []api.TailEntry
+	tailOffset  int  // first visible entry
+	tailPaused  bool // scrolled away from the bottom; new entries do not move the view
+	tailNew     int  // entries that arrived while paused
+	tailGen     int
+	tailErr     error
+
+	filter     api.SessionQuery // sessions and errors shown must match
+	showHidden bool             // include sessions the user hid
+	statePath  string           // where filter, sort and columns persist
+
+	sortBy        int      // index into sortKeys
+	sortReverse   bool     // flip the sort key's natural direction
+	columns       []string // row columns chosen with C; nil uses configColumns
+	configColumns []string // row columns from the config file; nil picks by width
+
+	help    bool     // help overlay shown over the current view
+	prompt  *prompt  // active text prompt, drawn over the footer
+	palette *palette // open command palette, drawn over the footer
+	recent  []string // recent palette commands, newest first
+	status  string   // one-shot message drawn over the footer
+
+	changes      map[string]highlight // sessions changed by recent refreshes
+	tracker      api.ChangeTracker    // diffs each load against the previous one
+	sessionDelta int                  // visible sessions gained in the last refresh
+	costDelta    float64              // visible total cost gained, once it reaches api.MinCostDelta
+	costCarry    api.CostAccumulator  // visible cost gained but not yet shown
+
+	snapshot   bool      // rendering once for -once: no footer, no focused section
+	mouse      bool      // mouse reporting is on; see -no-mouse
+	out        io.Writer // the program's output, for OSC 52 copies
+	chartHover int       // activity bucket under the mouse, or -1
+	lastClick  time.Time // when a session row was last clicked, for double-clicks
+	clickedSec int       // section and row of the last click
+	clickedRow int
+}
 
-	section    int    // focused section
-	sectionCur [4]int // cursor per section
+// prompt is a single-line text input. submit runs on enter; esc cancels.
+type prompt struct {
+	label  string
+	value  []rune
+	submit func(m *model, value string) tea.Cmd
 }
 
+// grouped returns the visible sessions of each section as of the last
+// regroup.
 func (m model) grouped() (active, idle, subs, crons []api.Session) {
-	for _, s := range m.dashboard.Sessions {
+	return m.groups[sectionActive], m.groups[sectionIdle], m.groups[sectionSubs], m.groups[sectionCrons]
+}
+
+// regroup splits the dashboard's visible sessions into sections in sort
+// order, then keeps each cursor on a row and in view. Call it whenever the
+// sessions, filter, hidden setting or sort change.
+func (m *model) regroup() {
+	active, idle, subs, crons := m.group()
+	m.groups = [4][]api.Session{sectionActive: active, sectionIdle: idle, sectionSubs: subs, sectionCrons: crons}
+	m.clampCursors()
+	m.followCursors()
+}
+
+func (m model) group() (active, idle, subs, crons []api.Session) {
+	for _, s := range m.sorted(m.dashboard.Sessions) {
+		if !m.visible(s) {
+			continue
+		}
 		switch s.Kind {
 		case "cron":
 			crons = append(crons, s)
@@ -81,6 +201,108 @@ func (m model) grouped() (active, idle, subs, crons []api.Session) {
 	return
 }
 
+// visible reports whether s passes the filter and hidden setting.
+func (m model) visible(s api.Session) bool {
+	if s.Hidden && !m.showHidden {
+		return false
+	}
+	return m.filter.Match(s, time.Now())
+}
+
+// setFilter parses and applies a filter query, keeping the current one if
+// it does not parse.
+func (m *model) setFilter(query string) {
+	q, err := api.ParseSessionQuery(query)
+	if err != nil {
+		m.status = "filter: " + err.Error()
+		return
+	}
+	m.filter = q
+	m.regroup()
+	m.filterErrors()
+	m.saveState()
+}
+
+// saveState persists the filter and hidden setting for the next run.
+func (m *model) saveState() {
+	st := config.State{
+		Filter:      m.filter.String(),
+		ShowHidden:  m.showHidden,
+		Sort:        sortKeys[m.sortBy].name,
+		SortReverse: m.sortReverse,
+		Columns:     m.columns,
+		Recent:      m.recent,
+	}
+	if err := config.SaveState(m.statePath, st); err != nil {
+		m.status = "saving state: " + err.Error()
+	}
+}
+
+// withTag replaces the tag: terms of query with tag, or drops them if tag
+// is empty.
+func withTag(query, tag string) string {
+	var words []string
+	for _, w := range strings.Fields(query) {
+		if !strings.HasPrefix(strings.ToLower(w), "tag:") {
+			words = append(words, w)
+		}
+	}
+	if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+		words = append(words, "tag:"+tag)
+	}
+	return strings.Join(words, " ")
+}
+
+// tagOf returns the value of the first tag: term in query.
+func tagOf(query string) string {
+	for _, w := range strings.Fields(query) {
+		if strings.HasPrefix(strings.ToLower(w), "tag:") {
+			return w[len("tag:"):]
+		}
+	}
+	return ""
+}
+
+// totals returns the counts and costs the stats bar shows.
+func (m model) totals() api.DashboardData {
+	if m.filter.IsZero() {
+		return m.dashboard
+	}
+	return m.filteredTotals()
+}
+
+// filteredTotals sums the visible sessions the way the dashboard sums all
+// of them.
+func (m model) filteredTotals() api.DashboardData {
+	var d api.DashboardData
+	for _, s := range m.dashboard.Sessions {
+		if !m.visible(s) {
+			continue
+		}
+		d.TotalCount++
+		d.TotalCost += s.TotalCost
+		d.TodayCost += s.TodayCost
+		d.ErrorCount += s.ErrorCount
+	}
+	return d
+}
+
+// filterErrors sets errors to allErrors without those of sessions that
+// are not visible.
+func (m *model) filterErrors() {
+	byID := make(map[string]api.Session, len(m.dashboard.Sessions))
+	for _, s := range m.dashboard.Sessions {
+		byID[s.SessionID] = s
+	}
+	m.errors = nil
+	for _, ev := range m.allErrors {
+		if s, ok := byID[ev.SessionID]; ok && !m.visible(s) {
+			continue
+		}
+		m.errors = append(m.errors, ev)
+	}
+}
+
 func (m model) sessionsForSection(sec int) []api.Session {
 	active, idle, subs, crons := m.grouped()
 	switch sec {
@@ -109,26 +331,27 @@ func (m model) selectedSession() (api.Session, bool) {
 	return api.Session{}, false
 }
 
-func initialModel() model {
-	dir := os.Getenv("OPENCLAW_DIR")
-	if dir == "" {
-		home, _ := os.UserHomeDir()
-		dir = home + "/.openclaw"
-	}
-	interval := 5 * time.Second
-	if v := os.Getenv("ANTENNA_INTERVAL"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			interval = d
+func initialModel(loader *config.Loader) model {
+	m := model{loader: loader, section: sectionActive, statePath: config.DefaultStatePath(), chartHover: -1}
+	st := config.LoadState(m.statePath)
+	m.filter, _ = api.ParseSessionQuery(st.Filter)
+	m.showHidden = st.ShowHidden
+	for i, k := range sortKeys {
+		if k.name == st.Sort {
+			m.sortBy = i
 		}
 	}
-	c := api.NewClient(dir)
-	return model{
-		client:    c,
-		dashboard: c.GetDashboard(),
-		hourly:    c.GetHourlyActivity(),
-		interval:  interval,
-		section:   sectionActive,
+	m.sortReverse = st.SortReverse
+	m.recent = st.Recent
+	if unknownColumns(st.Columns) == nil {
+		m.columns = st.Columns
 	}
+	cfg, err := loader.Load()
+	if err := errors.Join(err, m.applyConfig(cfg)); err != nil {
+		m.status = "config: " + err.Error()
+	}
+	m.loadSeq = 1 // the load Init starts
+	return m
 }
 
 func tickCmd(d time.Duration) tea.Cmd {
@@ -136,7 +359,114 @@ func tickCmd(d time.Duration) tea.Cmd {
 }
 
 func (m model) Init() tea.Cmd {
-	return tickCmd(m.interval)
+	return tea.Batch(m.load(), tickCmd(m.interval))
+}
+
+// ── Refresh ──
+
+// refresh starts a background load of the dashboard and the open view's
+// data. Results arrive as a dashboardLoadedMsg; one that finishes after a
+// later refresh has been applied is dropped.
+func (m *model) refresh() tea.Cmd {
+	m.loadSeq++
+	return m.load()
+}
+
+// refreshing reports whether a refresh is in flight.
+func (m model) refreshing() bool {
+	return m.appliedSeq < m.loadSeq
+}
+
+// load returns the command for refresh number loadSeq. It captures what it
+// needs from m so the model is never touched off the UI goroutine.
+func (m model) load() tea.Cmd {
+	client := m.client
+	msg := dashboardLoadedMsg{seq: m.loadSeq, view: m.view, lookback: m.heatmapLookback}
+	if m.view == viewDetail {
+		if s, ok := m.selectedSession(); ok {
+			msg.timelineID = s.SessionID
+		}
+	}
+	return func() tea.Msg {
+		msg.at = time.Now()
+		var errs []error
+		var err error
+		msg.dashboard, err = client.LoadDashboard()
+		errs = append(errs, err)
+		msg.activity, err = lastDayActivity(client)
+		errs = append(errs, err)
+		msg.forecast = client.GetForecast()
+		switch msg.view {
+		case viewErrors:
+			msg.errors = client.GetErrors()
+		case viewLatency:
+			msg.latency = client.GetLatency()
+		case viewHeatmap:
+			msg.heatmap, err = client.GetHeatmap(heatmapLookbacks[msg.lookback], api.ActivityFilter{})
+			errs = append(errs, err)
+		case viewDetail:
+			if msg.timelineID != "" {
+				msg.timeline, _ = client.GetSessionTimeline(msg.timelineID, 0)
+			}
+		}
+		msg.err = errors.Join(errs...)
+		return msg
+	}
+}
+
+// applyLoad installs a refresh's results, skipping view data for a view
+// that has since been left.
+func (m *model) applyLoad(msg dashboardLoadedMsg) {
+	if msg.seq <= m.appliedSeq {
+		return
+	}
+	m.appliedSeq = msg.seq
+	m.lastRefresh = msg.at
+	m.err = msg.err
+	if msg.err != nil && msg.dashboard.Sessions == nil {
+		// Keep showing the last good data next to the error.
+		return
+	}
+	prev, prevTotals := m.dashboard, m.totals()
+	m.dashboard = msg.dashboard
+	m.activity = msg.activity
+	m.forecast = msg.forecast
+	sel, ok := m.selectedSession()
+	m.regroup()
+	if ok && m.view == viewDetail {
+		m.selectSession(sel.SessionID)
+	}
+	if m.reselectID != "" && msg.seq >= m.reselectSeq {
+		m.selectSession(m.reselectID)
+		m.reselectID = ""
+	}
+	diff := m.tracker.Track(m.dashboard)
+	if prev.Sessions != nil {
+		m.trackChanges(diff, prevTotals, msg.at)
+	}
+
+	if msg.view != m.view {
+		return
+	}
+	switch m.view {
+	case viewErrors:
+		m.allErrors = msg.errors
+		m.filterErrors()
+		m.errorsOffset = clampInt(m.errorsOffset, 0, maxInt(len(m.errors)-1, 0))
+		m.viewLoaded = true
+	case viewLatency:
+		m.latency = msg.latency
+		m.viewLoaded = true
+	case viewHeatmap:
+		if msg.lookback == m.heatmapLookback {
+			m.heatmap = msg.heatmap
+			m.viewLoaded = true
+		}
+	case viewDetail:
+		if s, ok := m.selectedSession(); ok && s.SessionID == msg.timelineID {
+			m.timeline = msg.timeline
+		}
+	}
 }
 
 // Navigation grid:
@@ -154,6 +484,271 @@ func (m *model) moveSection(grid [4]int) {
 	}
 }
 
+// ── Section Viewports ──
+
+// size returns the terminal size, defaulting before the first resize.
+func (m model) size() (w, h int) {
+	w, h = m.width, m.height
+	if w == 0 {
+		w = 120
+	}
+	if h == 0 {
+		h = 40
+	}
+	return w, h
+}
+
+// sectionRows returns how many session rows each section shows. The left
+// column stacks active over idle and the right subs over crons; each stack
+// splits its height between its two sections, giving rows one does not
+// need to the other.
+func (m model) sectionRows() [4]int {
+	w, h := m.size()
+	availRows := panelRows(h)
+	if w < 90 {
+		// Stacked layout: left panel above right panel.
+		availRows /= 2
+	}
+	var rows [4]int
+	rows[sectionActive], rows[sectionIdle] = splitRows(availRows, len(m.groups[sectionActive]), len(m.groups[sectionIdle]))
+	rows[sectionSubs], rows[sectionCrons] = splitRows(availRows, len(m.groups[sectionSubs]), len(m.groups[sectionCrons]))
+	return rows
+}
+
+// panelRows is the height of the dashboard's session panels in a terminal
+// h rows tall.
+func panelRows(h int) int {
+	chartRows := 12 // approx: header + 8 bars + axis + labels + divider
+	statsRows := 2
+	footerRows := 2
+	availRows := h - statsRows - chartRows - footerRows - 1
+	if availRows < 8 {
+		availRows = 8
+	}
+	return availRows
+}
+
+// splitRows divides total lines between two sections of a and b sessions,
+// after their headers and the blank line between them. Each gets at least
+// one row.
+func splitRows(total, a, b int) (int, int) {
+	body := maxInt(total-3, 2)
+	ra := body / 2
+	rb := body - ra
+	need := func(n int) int { return maxInt(n, 1) }
+	if need(a) < ra {
+		rb += ra - need(a)
+		ra = need(a)
+	} else if need(b) < rb {
+		ra += rb - need(b)
+		rb = need(b)
+	}
+	return ra, rb
+}
+
+// followCursors scrolls each section so its cursor is visible.
+func (m *model) followCursors() {
+	rows := m.sectionRows()
+	for sec := 0; sec < 4; sec++ {
+		n, r := len(m.groups[sec]), rows[sec]
+		off, cur := m.sectionOff[sec], m.sectionCur[sec]
+		if cur < off {
+			off = cur
+		}
+		if cur >= off+r {
+			off = cur - r + 1
+		}
+		m.sectionOff[sec] = clampInt(off, 0, maxInt(n-r, 0))
+	}
+}
+
+// moveCursor moves the focused section's cursor by delta rows, stopping at
+// either end.
+func (m *model) moveCursor(delta int) {
+	n := m.sectionLen(m.section)
+	if n == 0 {
+		return
+	}
+	m.sectionCur[m.section] = clampInt(m.sectionCur[m.section]+delta, 0, n-1)
+}
+
+// visibleRange returns the rows of sec to draw, [from, to).
+func (m model) visibleRange(sec, rows int) (from, to int) {
+	n := len(m.groups[sec])
+	from = clampInt(m.sectionOff[sec], 0, n)
+	return from, minInt(from+rows, n)
+}
+
+// scrollHint marks rows above and below a section's viewport.
+func (t Theme) scrollHint(above, below int) string {
+	var parts []string
+	if above > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", above))
+	}
+	if below > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", below))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(t.Dim).Render("  " + strings.Join(parts, " "))
+}
+
+// ── Mouse ──
+
+// doubleClick is the longest gap between two clicks on a row that opens it.
+const doubleClick = 400 * time.Millisecond
+
+// wheelRows is how far one wheel step scrolls.
+const wheelRows = 3
+
+// panelHit is what one line of a dashboard panel shows: a section's header
+// (row -1) or one of its session rows. sec is -1 for blank lines.
+type panelHit struct{ sec, row int }
+
+// panelHits lists the lines renderLeftPanel or renderRightPanel draws for
+// the sections top and bottom.
+func (m model) panelHits(top, bottom int) []panelHit {
+	rows := m.sectionRows()
+	var hits []panelHit
+	for i, sec := range []int{top, bottom} {
+		if i > 0 {
+			hits = append(hits, panelHit{-1, 0})
+		}
+		hits = append(hits, panelHit{sec, -1})
+		from, to := m.visibleRange(sec, rows[sec])
+		if from == to {
+			hits = append(hits, panelHit{sec, -1}) // "No sessions" placeholder
+		}
+		for r := from; r < to; r++ {
+			hits = append(hits, panelHit{sec, r})
+		}
+	}
+	return hits
+}
+
+// chartTop is the screen line of the activity chart header.
+const chartTop = 2
+
+// dashboardHit returns the section and row under screen cell (x, y).
+func (m model) dashboardHit(x, y int) panelHit {
+	none := panelHit{-1, 0}
+	w, h := m.size()
+	y -= chartTop + strings.Count(m.renderActivityChart(w), "\n") + 2
+	if y < 0 {
+		return none
+	}
+	left := m.panelHits(sectionActive, sectionIdle)
+	right := m.panelHits(sectionSubs, sectionCrons)
+	leftW, _, side := dashboardColumns(w)
+	switch {
+	case side && y >= panelRows(h):
+		return none
+	case side && x < leftW && y < len(left):
+		return left[y]
+	case side && x > leftW && y < len(right):
+		return right[y]
+	case !side && y < len(left):
+		return left[y]
+	case !side && y-len(left) < len(right):
+		return right[y-len(left)]
+	}
+	return none
+}
+
+// chartBucket returns the activity bucket drawn at screen column x of the
+// bar chart, or -1. Between bars it picks the bar to the left.
+func (m model) chartBucket(x int) int {
+	w, _ := m.size()
+	n := len(m.activity)
+	barAreaW := maxInt(w-chartLabelW-1, 10)
+	col := x - chartLabelW
+	if n == 0 || col < 0 || col >= barAreaW {
+		return -1
+	}
+	return minInt(((col+1)*n-1)/barAreaW, n-1)
+}
+
+// updateMouse handles mouse events: on the dashboard a click focuses a
+// section and selects a row, a second click opens it, the wheel scrolls
+// the section under the pointer and hovering the chart shows a bucket's
+// numbers. The wheel also scrolls the errors and follow views.
+func (m model) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.prompt != nil || m.palette != nil || m.help {
+		return m, nil
+	}
+	wheel := 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		wheel = -wheelRows
+	case tea.MouseButtonWheelDown:
+		wheel = wheelRows
+	}
+
+	switch m.view {
+	case viewErrors:
+		if wheel != 0 {
+			m.errorsOffset = clampInt(m.errorsOffset+wheel, 0, maxInt(len(m.errors)-1, 0))
+		}
+		return m, nil
+	case viewTail:
+		key := "down"
+		if wheel < 0 {
+			key = "up"
+		}
+		for i := 0; i < wheelRows && wheel != 0; i++ {
+			m.updateTail(key)
+		}
+		return m, nil
+	case viewDashboard:
+	default:
+		return m, nil
+	}
+
+	w, _ := m.size()
+	chartRows := strings.Count(m.renderActivityChart(w), "\n")
+	m.chartHover = -1
+	if msg.Y > chartTop && msg.Y <= chartTop+chartRows {
+		m.chartHover = m.chartBucket(msg.X)
+	}
+
+	hit := m.dashboardHit(msg.X, msg.Y)
+	if hit.sec < 0 {
+		return m, nil
+	}
+	if wheel != 0 {
+		m.scrollSection(hit.sec, wheel)
+		return m, nil
+	}
+	if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	m.status = ""
+	m.section = hit.sec
+	if hit.row < 0 {
+		return m, nil
+	}
+	m.sectionCur[hit.sec] = hit.row
+	if time.Since(m.lastClick) < doubleClick && m.clickedSec == hit.sec && m.clickedRow == hit.row {
+		m.lastClick = time.Time{}
+		m.view = viewDetail
+		cmd := m.loadTimeline()
+		return m, cmd
+	}
+	m.lastClick, m.clickedSec, m.clickedRow = time.Now(), hit.sec, hit.row
+	return m, nil
+}
+
+// scrollSection moves sec's viewport by delta rows, dragging its cursor
+// along when it would leave the view.
+func (m *model) scrollSection(sec, delta int) {
+	rows := m.sectionRows()[sec]
+	n := len(m.groups[sec])
+	off := clampInt(m.sectionOff[sec]+delta, 0, maxInt(n-rows, 0))
+	m.sectionOff[sec] = off
+	m.sectionCur[sec] = clampInt(m.sectionCur[sec], off, maxInt(minInt(off+rows, n)-1, 0))
+}
+
 func (m *model) clampCursors() {
 	for i := 0; i < 4; i++ {
 		max := m.sectionLen(i)
@@ -168,88 +763,231 @@ func (m *model) clampCursors() {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-		switch key {
-		case "q", "ctrl+c":
-			if m.view == viewDetail {
-				m.view = viewDashboard
-				return m, nil
-			}
-			return m, tea.Quit
+		if m.prompt != nil {
+			return m.updatePrompt(msg)
+		}
+		m.status = ""
+		if m.help {
+			m.help = false
+			return m, nil
+		}
+		if m.palette != nil {
+			return m.updatePalette(msg)
+		}
+		return m.runAction(m.keys.action(msg.String(), m.view))
 
-		case "j", "down":
-			if m.view == viewDashboard {
-				max := m.sectionLen(m.section)
-				if m.sectionCur[m.section] < max-1 {
-					m.sectionCur[m.section]++
-				}
-			}
-		case "k", "up":
-			if m.view == viewDashboard {
-				if m.sectionCur[m.section] > 0 {
-					m.sectionCur[m.section]--
-				}
-			}
-		case "h":
-			if m.view == viewDashboard {
-				m.moveSection(navLeft)
-			}
-		case "l":
-			if m.view == viewDashboard {
-				m.moveSection(navRight)
-			}
-		case "ctrl+j":
-			if m.view == viewDashboard {
-				m.moveSection(navDown)
-			}
-		case "ctrl+k":
-			if m.view == viewDashboard {
-				m.moveSection(navUp)
-			}
-		case "ctrl+h":
-			if m.view == viewDashboard {
-				m.moveSection(navLeft)
-			}
-		case "ctrl+l":
-			if m.view == viewDashboard {
-				m.moveSection(navRight)
-			}
-		case "enter":
-			if m.view == viewDashboard {
-				if _, ok := m.selectedSession(); ok {
-					m.view = viewDetail
-				}
-			}
-		case "esc", "backspace":
-			m.view = viewDashboard
-		case "tab":
-			if m.view == viewDashboard {
-				order := []int{sectionActive, sectionSubs, sectionIdle, sectionCrons}
-				for i, s := range order {
-					if s == m.section {
-						m.section = order[(i+1)%len(order)]
-						break
-					}
-				}
-			}
-		case "r":
-			m.dashboard = m.client.GetDashboard()
-			m.hourly = m.client.GetHourlyActivity()
-			m.clampCursors()
+	case tickMsg:
+		if m.loader.Changed() {
+			m.reloadConfig()
+		}
+		// A slow load is left to finish rather than piling up behind it.
+		if m.refreshing() {
+			return m, tickCmd(m.interval)
 		}
+		return m, tea.Batch(m.refresh(), tickCmd(m.interval))
+
+	case dashboardLoadedMsg:
+		m.applyLoad(msg)
+		return m, m.expireHighlights()
+
+	case highlightExpiredMsg:
 		return m, nil
 
-	case tickMsg:
-		m.dashboard = m.client.GetDashboard()
-		m.hourly = m.client.GetHourlyActivity()
-		m.clampCursors()
-		return m, tickCmd(m.interval)
+	case copiedMsg:
+		if msg.err != nil {
+			m.status = "copy failed: " + msg.err.Error()
+		} else {
+			m.status = "copied " + msg.label
+		}
+		return m, nil
+
+	case timelineLoadedMsg:
+		if s, ok := m.selectedSession(); ok && m.view == viewDetail && s.SessionID == msg.id {
+			m.timeline = msg.timeline
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+
+	case tailTickMsg:
+		if m.view != viewTail || int(msg) != m.tailGen {
+			return m, nil
+		}
+		m.readTail()
+		return m, tea.Tick(tailInterval, func(time.Time) tea.Msg { return tailTickMsg(m.tailGen) })
+
+	case externalDoneMsg:
+		return m.externalDone(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.followCursors()
+		return m, nil
+	}
+	return m, nil
+}
+
+// runAction performs a keymap action in the current view.
+func (m model) runAction(action string) (tea.Model, tea.Cmd) {
+	if m.view == viewTail && m.updateTail(action) {
 		return m, nil
 	}
+	switch action {
+	case "quit":
+		if m.view != viewDashboard {
+			m.view = viewDashboard
+			return m, nil
+		}
+		return m, tea.Quit
+	case "help":
+		m.help = true
+	case "palette":
+		m.palette = &palette{}
+		m.filterPalette()
+
+	case "down":
+		if m.view == viewDashboard {
+			m.moveCursor(1)
+		} else if m.view == viewErrors {
+			if m.errorsOffset < len(m.errors)-1 {
+				m.errorsOffset++
+			}
+		}
+	case "up":
+		if m.view == viewDashboard {
+			m.moveCursor(-1)
+		} else if m.view == viewErrors {
+			if m.errorsOffset > 0 {
+				m.errorsOffset--
+			}
+		}
+	case "pageDown", "pageUp", "top", "bottom":
+		page := m.sectionRows()[m.section]
+		if m.view == viewErrors {
+			page = m.errorRows()
+		}
+		var delta int
+		switch action {
+		case "pageDown":
+			delta = page
+		case "pageUp":
+			delta = -page
+		case "top":
+			delta = -math.MaxInt32
+		case "bottom":
+			delta = math.MaxInt32
+		}
+		if m.view == viewDashboard {
+			m.moveCursor(delta)
+		} else if m.view == viewErrors {
+			m.errorsOffset = clampInt(m.errorsOffset+delta, 0, maxInt(len(m.errors)-1, 0))
+		}
+	case "errors":
+		m.allErrors, m.errors = nil, nil
+		m.errorsOffset = 0
+		m.viewLoaded = false
+		m.view = viewErrors
+		return m, m.refresh()
+	case "latency":
+		m.latency = api.LatencyReport{}
+		m.viewLoaded = false
+		m.view = viewLatency
+		return m, m.refresh()
+	case "follow":
+		return m, m.startTail()
+	case "rename", "tags", "note", "pin", "hide":
+		cmd := m.annotate(action)
+		return m, cmd
+	case "pager", "pagerRaw", "editor":
+		cmd := m.openExternal(action)
+		return m, cmd
+	case "copyID", "copyPath", "copySummary", "copyMarkdown":
+		cmd := m.copySession(strings.ToLower(strings.TrimPrefix(action, "copy")))
+		return m, cmd
+	case "showHidden":
+		m.showHidden = !m.showHidden
+		m.regroup()
+		m.saveState()
+	case "filter":
+		m.prompt = &prompt{label: "Filter", value: []rune(m.filter.String()), submit: func(m *model, v string) tea.Cmd {
+			m.setFilter(v)
+			return nil
+		}}
+	case "tagFilter":
+		m.prompt = &prompt{label: "Filter by tag", value: []rune(tagOf(m.filter.String())), submit: func(m *model, v string) tea.Cmd {
+			m.setFilter(withTag(m.filter.String(), v))
+			return nil
+		}}
+	case "sort", "sortReverse":
+		sel, ok := m.selectedSession()
+		if action == "sort" {
+			m.sortBy = (m.sortBy + 1) % len(sortKeys)
+			m.sortReverse = false
+		} else {
+			m.sortReverse = !m.sortReverse
+		}
+		m.regroup()
+		if ok {
+			m.selectSession(sel.SessionID)
+		}
+		m.saveState()
+	case "columns":
+		m.prompt = &prompt{label: "Columns (" + strings.Join(columnNames, " ") + ")", value: []rune(strings.Join(m.rowColumns(), " ")), submit: func(m *model, v string) tea.Cmd {
+			cols := strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+			if unknown := unknownColumns(cols); unknown != nil {
+				m.status = "unknown columns: " + strings.Join(unknown, ", ")
+				return nil
+			}
+			m.columns = cols
+			m.saveState()
+			return nil
+		}}
+	case "heatmap":
+		m.heatmap = nil
+		m.viewLoaded = false
+		m.view = viewHeatmap
+		return m, m.refresh()
+	case "heatmapPrev", "heatmapNext":
+		step := 1
+		if action == "heatmapPrev" {
+			step = len(heatmapLookbacks) - 1
+		}
+		m.heatmapLookback = (m.heatmapLookback + step) % len(heatmapLookbacks)
+		m.heatmap = nil
+		m.viewLoaded = false
+		return m, m.refresh()
+	case "heatmapCost":
+		m.heatmapCost = !m.heatmapCost
+	case "left":
+		m.moveSection(navLeft)
+	case "right":
+		m.moveSection(navRight)
+	case "sectionDown":
+		m.moveSection(navDown)
+	case "sectionUp":
+		m.moveSection(navUp)
+	case "open":
+		if _, ok := m.selectedSession(); ok {
+			m.view = viewDetail
+			cmd := m.loadTimeline()
+			return m, cmd
+		}
+	case "back":
+		m.view = viewDashboard
+	case "nextSection":
+		order := []int{sectionActive, sectionSubs, sectionIdle, sectionCrons}
+		for i, s := range order {
+			if s == m.section {
+				m.section = order[(i+1)%len(order)]
+				break
+			}
+		}
+	case "refresh":
+		return m, m.refresh()
+	}
+	m.followCursors()
 	return m, nil
 }
 
@@ -265,50 +1003,284 @@ func (m model) View() string {
 
 	var b strings.Builder
 
-	switch m.view {
-	case viewDashboard:
+	switch {
+	case m.help:
+		b.WriteString(m.renderStatsBar(w))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderHelp(w))
+	case m.view == viewDashboard:
 		b.WriteString(m.renderStatsBar(w))
 		b.WriteString("\n")
 		b.WriteString(m.renderDashboard(w, h))
-	case viewDetail:
+	case m.view == viewDetail:
 		b.WriteString(m.renderStatsBar(w))
 		b.WriteString("\n")
 		b.WriteString(m.renderDetail(w, h))
+	case m.view == viewErrors:
+		b.WriteString(m.renderStatsBar(w))
+		b.WriteString("\n")
+		b.WriteString(m.renderErrors(w, h))
+	case m.view == viewLatency:
+		b.WriteString(m.renderStatsBar(w))
+		b.WriteString("\n")
+		b.WriteString(m.renderLatency(w))
+	case m.view == viewHeatmap:
+		b.WriteString(m.renderStatsBar(w))
+		b.WriteString("\n")
+		b.WriteString(m.renderHeatmap())
+	case m.view == viewTail:
+		b.WriteString(m.renderStatsBar(w))
+		b.WriteString("\n")
+		b.WriteString(m.renderTail(w))
 	}
 
-	return b.String()
+	out := b.String()
+	if line := m.renderPromptLine(); line != "" {
+		if i := strings.LastIndex(out, "\n"); i >= 0 {
+			out = out[:i+1] + line
+		}
+	}
+	if m.palette != nil {
+		lines := strings.Split(out, "\n")
+		pal := m.renderPalette(w)
+		out = strings.Join(append(lines[:maxInt(len(lines)-len(pal), 0)], pal...), "\n")
+	}
+	return out
+}
+
+// ── Config ──
+
+// applyConfig switches the model to cfg: a new client, interval, time zone
+// and key bindings.
+func (m *model) applyConfig(cfg config.Config) error {
+	m.client = cfg.NewClient()
+	m.interval = time.Duration(cfg.Interval)
+	overrides := make(map[string][]string, len(cfg.Keys))
+	for action, keys := range cfg.Keys {
+		overrides[action] = keys
+	}
+	keys, err := newKeyMap(overrides)
+	m.keys = keys
+	m.configColumns = nil
+	if unknown := unknownColumns(cfg.Columns); unknown != nil {
+		err = errors.Join(err, fmt.Errorf("unknown columns: %s", strings.Join(unknown, ", ")))
+	} else {
+		m.configColumns = cfg.Columns
+	}
+	theme, themeErr := loadTheme(cfg.Theme, m.loader.Path)
+	if themeErr != nil {
+		theme, _ = loadTheme("auto", "")
+	}
+	m.theme = theme
+	return errors.Join(err, themeErr)
+}
+
+// reloadConfig re-reads the config file after it changes on disk. Loads
+// already in flight used the old client, so their results are dropped.
+func (m *model) reloadConfig() {
+	m.appliedSeq = m.loadSeq
+	cfg, err := m.loader.Load()
+	if err := errors.Join(err, m.applyConfig(cfg)); err != nil {
+		m.status = "config: " + err.Error()
+		return
+	}
+	m.status = "config reloaded"
+}
+
+// ── Prompt & Annotations ──
+
+func (m model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.prompt
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.prompt = nil
+		cmd := p.submit(&m, string(p.value))
+		return m, cmd
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.prompt = nil
+	case tea.KeyBackspace:
+		if len(p.value) > 0 {
+			p.value = p.value[:len(p.value)-1]
+		}
+	case tea.KeyCtrlU:
+		p.value = nil
+	case tea.KeyRunes, tea.KeySpace:
+		p.value = append(p.value, msg.Runes...)
+	}
+	return m, nil
+}
+
+func (m model) renderPromptLine() string {
+	if m.prompt != nil {
+		return lipgloss.NewStyle().Foreground(m.theme.Green).Bold(true).Render("  "+m.prompt.label+": ") +
+			lipgloss.NewStyle().Foreground(m.theme.Bright).Render(string(m.prompt.value)) +
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render("█") +
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  enter save  esc cancel")
+	}
+	if m.status != "" {
+		return lipgloss.NewStyle().Foreground(m.theme.Orange).Render("  " + m.status)
+	}
+	return ""
+}
+
+// selectSession moves the cursor to the session with the given ID, if it
+// is visible.
+func (m *model) selectSession(id string) {
+	for sec := 0; sec < 4; sec++ {
+		for i, s := range m.sessionsForSection(sec) {
+			if s.SessionID == id {
+				m.section = sec
+				m.sectionCur[sec] = i
+				m.followCursors()
+				return
+			}
+		}
+	}
+}
+
+// annotate runs an annotation action on the selected session: rename,
+// tags, note, pin or hide. Saving refreshes in the background and keeps
+// the session selected.
+func (m *model) annotate(action string) tea.Cmd {
+	s, ok := m.selectedSession()
+	if !ok {
+		return nil
+	}
+	id := s.SessionID
+	save := func(m *model, fn func(*api.Annotation)) tea.Cmd {
+		if err := m.client.UpdateAnnotation(id, fn); err != nil {
+			m.status = "annotation not saved: " + err.Error()
+			return nil
+		}
+		cmd := m.refresh()
+		m.reselectID, m.reselectSeq = id, m.loadSeq
+		return cmd
+	}
+
+	switch action {
+	case "rename":
+		original := s.Name
+		if s.OriginalName != "" {
+			original = s.OriginalName
+		}
+		m.prompt = &prompt{label: "Rename", value: []rune(s.Name), submit: func(m *model, v string) tea.Cmd {
+			if strings.TrimSpace(v) == original {
+				v = ""
+			}
+			return save(m, func(a *api.Annotation) { a.Name = v })
+		}}
+	case "tags":
+		m.prompt = &prompt{label: "Tags", value: []rune(strings.Join(s.Tags, " ")), submit: func(m *model, v string) tea.Cmd {
+			return save(m, func(a *api.Annotation) { a.Tags = api.ParseTags(v) })
+		}}
+	case "note":
+		m.prompt = &prompt{label: "Note", value: []rune(s.Note), submit: func(m *model, v string) tea.Cmd {
+			return save(m, func(a *api.Annotation) { a.Note = v })
+		}}
+	case "pin":
+		return save(m, func(a *api.Annotation) { a.Pinned = !a.Pinned })
+	case "hide":
+		if !s.Hidden && !m.showHidden {
+			m.view = viewDashboard
+		}
+		return save(m, func(a *api.Annotation) { a.Hidden = !a.Hidden })
+	}
+	return nil
 }
 
 // ── Stats Bar ──
 func (m model) renderStatsBar(w int) string {
 	active, _, subs, crons := m.grouped()
 
-	sep := lipgloss.NewStyle().Foreground(colorDimmer).Render(" │ ")
+	sep := lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render(" │ ")
+
+	// Green dot + "Live" and the last refresh time, or the load error
+	live := lipgloss.NewStyle().Foreground(m.theme.Green).Render("● ") +
+		lipgloss.NewStyle().Foreground(m.theme.Green).Bold(true).Render("Live")
+	switch {
+	case m.err != nil:
+		live = lipgloss.NewStyle().Foreground(m.theme.Red).Render("● ") +
+			lipgloss.NewStyle().Foreground(m.theme.Red).Bold(true).Render(truncate(m.err.Error(), 60))
+	case m.lastRefresh.IsZero():
+		live = lipgloss.NewStyle().Foreground(m.theme.Dim).Render("● Loading…")
+	default:
+		live += lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" " + m.client.In(m.lastRefresh).Format("15:04:05"))
+	}
+	if m.refreshing() && !m.lastRefresh.IsZero() {
+		live += lipgloss.NewStyle().Foreground(m.theme.Cyan).Render(" ↻")
+	}
 
-	// Pulsing green dot + "Live"
-	live := lipgloss.NewStyle().Foreground(colorGreen).Render("● ") +
-		lipgloss.NewStyle().Foreground(colorGreen).Bold(true).Render("Live")
+	// With a filter, counts and costs cover only the matching sessions.
+	totals := m.totals()
 
 	// Big session count
-	count := lipgloss.NewStyle().Bold(true).Foreground(colorWhite).Render(fmt.Sprintf("%d", m.dashboard.TotalCount)) +
-		lipgloss.NewStyle().Foreground(colorDim).Render(" sessions")
+	count := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Bright).Render(fmt.Sprintf("%d", totals.TotalCount)) +
+		lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" sessions")
+	switch {
+	case m.sessionDelta > 0:
+		count += lipgloss.NewStyle().Foreground(m.theme.Cyan).Render(fmt.Sprintf(" +%d", m.sessionDelta))
+	case m.sessionDelta < 0:
+		count += lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf(" −%d", -m.sessionDelta))
+	}
 
 	// Colored counts
-	activeCount := lipgloss.NewStyle().Bold(true).Foreground(colorGreen).Render(fmt.Sprintf("%d", len(active))) +
-		lipgloss.NewStyle().Foreground(colorDim).Render(" active")
-	subCount := lipgloss.NewStyle().Bold(true).Foreground(colorPurple).Render(fmt.Sprintf("%d", len(subs))) +
-		lipgloss.NewStyle().Foreground(colorDim).Render(" sub")
-	cronCount := lipgloss.NewStyle().Bold(true).Foreground(colorOrange).Render(fmt.Sprintf("%d", len(crons))) +
-		lipgloss.NewStyle().Foreground(colorDim).Render(" cron")
+	activeCount := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Green).Render(fmt.Sprintf("%d", len(active))) +
+		lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" active")
+	subCount := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Purple).Render(fmt.Sprintf("%d", len(subs))) +
+		lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" sub")
+	cronCount := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Orange).Render(fmt.Sprintf("%d", len(crons))) +
+		lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" cron")
 
 	left := live + sep + count + sep + activeCount + sep + subCount + sep + cronCount
-
-	// Right: costs
-	todayCost := lipgloss.NewStyle().Foreground(colorDim).Render("Today ") +
-		lipgloss.NewStyle().Bold(true).Foreground(colorGreen).Render(fmt.Sprintf("$%.2f", m.dashboard.TodayCost))
-	totalCost := lipgloss.NewStyle().Foreground(colorDim).Render("  Total ") +
-		lipgloss.NewStyle().Bold(true).Foreground(colorWhite).Render(fmt.Sprintf("$%.2f", m.dashboard.TotalCost))
-	right := todayCost + totalCost
+	if !m.filter.IsZero() {
+		left += sep + lipgloss.NewStyle().Bold(true).Foreground(m.theme.Purple).Render("/"+truncate(m.filter.String(), 40))
+	}
+	if m.showHidden {
+		left += sep + lipgloss.NewStyle().Foreground(m.theme.Dim).Render("+hidden")
+	}
+	if m.sortBy != 0 || m.sortReverse {
+		left += sep + lipgloss.NewStyle().Foreground(m.theme.Cyan).Render(m.sortLabel())
+	}
+	if totals.ErrorCount > 0 {
+		left += sep + lipgloss.NewStyle().Bold(true).Foreground(m.theme.Red).Render(fmt.Sprintf("%d", totals.ErrorCount)) +
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" errors")
+	}
+
+	// Right: costs, with end-of-day and end-of-month projections
+	dim := lipgloss.NewStyle().Foreground(m.theme.Dim)
+	fc := m.forecast
+	eodStyle := dim
+	if fc.OverDailyBudget {
+		eodStyle = lipgloss.NewStyle().Foreground(m.theme.Red)
+	}
+	todayCost := dim.Render("Today ") +
+		lipgloss.NewStyle().Bold(true).Foreground(m.theme.Green).Render(fmt.Sprintf("$%.2f", totals.TodayCost)) +
+		eodStyle.Render(fmt.Sprintf(" → $%.2f", fc.EndOfDay.Expected))
+	monthColor := m.theme.Fg
+	if fc.OverBudget {
+		monthColor = m.theme.Red
+	}
+	monthCost := dim.Render("  Month → ") +
+		lipgloss.NewStyle().Bold(true).Foreground(monthColor).Render(fmt.Sprintf("$%.2f", fc.EndOfMonth.Expected))
+	band := dim.Render(fmt.Sprintf(" ($%.0f–%.0f)", fc.EndOfMonth.Low, fc.EndOfMonth.High))
+	if fc.OverBudget {
+		band += lipgloss.NewStyle().Foreground(m.theme.Red).Render(fmt.Sprintf(" > $%.0f budget", fc.MonthlyBudget))
+	}
+	totalCost := dim.Render("  Total ") +
+		lipgloss.NewStyle().Bold(true).Foreground(m.theme.Bright).Render(fmt.Sprintf("$%.2f", totals.TotalCost))
+	if m.costDelta > 0 {
+		totalCost += lipgloss.NewStyle().Foreground(m.theme.Cyan).Render(fmt.Sprintf(" +$%.2f", m.costDelta))
+	}
+	right := todayCost + monthCost + band + totalCost
+	if !m.filter.IsZero() {
+		// Projections cover all sessions, so they are left out while filtering.
+		todayCost = dim.Render("Today ") +
+			lipgloss.NewStyle().Bold(true).Foreground(m.theme.Green).Render(fmt.Sprintf("$%.2f", totals.TodayCost))
+		right = todayCost + totalCost
+	} else if lipgloss.Width(left)+lipgloss.Width(right) >= w {
+		// Drop the band on narrow terminals; the month color still flags the budget.
+		right = todayCost + monthCost + totalCost
+	}
 
 	leftLen := lipgloss.Width(left)
 	rightLen := lipgloss.Width(right)
@@ -318,7 +1290,7 @@ func (m model) renderStatsBar(w int) string {
 	}
 
 	bar := left + strings.Repeat(" ", gap) + right
-	divider := lipgloss.NewStyle().Foreground(colorBorder).Render(strings.Repeat("─", w))
+	divider := lipgloss.NewStyle().Foreground(m.theme.Border).Render(strings.Repeat("─", w))
 
 	return bar + "\n" + divider
 }
@@ -330,30 +1302,16 @@ func (m model) renderDashboard(w, h int) string {
 	// Activity chart (full width)
 	b.WriteString(m.renderActivityChart(w))
 	b.WriteString("\n")
-	b.WriteString(lipgloss.NewStyle().Foreground(colorBorder).Render(strings.Repeat("─", w)))
+	b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Border).Render(strings.Repeat("─", w)))
 	b.WriteString("\n")
 
 	active, idle, subs, crons := m.grouped()
 
 	// Two-column layout
-	useColumns := w >= 90
-	var leftW, rightW int
-	if useColumns {
-		rightW = clampInt(w/3, 28, 55)
-		leftW = w - rightW - 1 // 1 for separator
-	} else {
-		leftW = w
-		rightW = w
-	}
+	leftW, rightW, useColumns := dashboardColumns(w)
 
 	// Calculate available rows
-	chartRows := 12 // approx: header + 8 bars + axis + labels + divider
-	statsRows := 2
-	footerRows := 2
-	availRows := h - statsRows - chartRows - footerRows - 1
-	if availRows < 8 {
-		availRows = 8
-	}
+	availRows := panelRows(h)
 
 	// Build left panel: active + idle
 	leftLines := m.renderLeftPanel(active, idle, leftW, availRows)
@@ -363,7 +1321,7 @@ func (m model) renderDashboard(w, h int) string {
 		rightLines := m.renderRightPanel(subs, crons, rightW, availRows)
 
 		maxLines := maxInt(len(leftLines), len(rightLines))
-		sep := lipgloss.NewStyle().Foreground(colorDimmer).Render("│")
+		sep := lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render("│")
 
 		for i := 0; i < maxLines && i < availRows; i++ {
 			left := ""
@@ -377,10 +1335,7 @@ func (m model) renderDashboard(w, h int) string {
 			b.WriteString(padRight(left, leftW) + sep + padRight(right, rightW) + "\n")
 		}
 	} else {
-		for i, line := range leftLines {
-			if i >= availRows/2 {
-				break
-			}
+		for _, line := range leftLines {
 			b.WriteString(line + "\n")
 		}
 		rightLines := m.renderRightPanel(subs, crons, rightW, availRows/2)
@@ -391,29 +1346,37 @@ func (m model) renderDashboard(w, h int) string {
 
 	// Footer
 	b.WriteString("\n")
-	footerDim := lipgloss.NewStyle().Foreground(colorDimmer)
-	footerKey := lipgloss.NewStyle().Foreground(colorDim)
-	b.WriteString(footerDim.Render(" ") +
-		footerKey.Render("j/k") + footerDim.Render(" move  ") +
-		footerKey.Render("h/l") + footerDim.Render(" column  ") +
-		footerKey.Render("ctrl+j/k") + footerDim.Render(" section  ") +
-		footerKey.Render("enter") + footerDim.Render(" detail  ") +
-		footerKey.Render("tab") + footerDim.Render(" cycle  ") +
-		footerKey.Render("r") + footerDim.Render(" refresh  ") +
-		footerKey.Render("q") + footerDim.Render(" quit"))
+	b.WriteString(m.renderFooter(viewDashboard, w))
 
 	return b.String()
 }
 
+// dashboardColumns returns the widths of the dashboard's left and right
+// panels, and whether they sit side by side with a one-column separator
+// or are stacked full width.
+func dashboardColumns(w int) (leftW, rightW int, side bool) {
+	if w < 90 {
+		return w, w, false
+	}
+	rightW = clampInt(w/3, 28, 55)
+	return w - rightW - 1, rightW, true
+}
+
+// errorRows is how many errors the errors view shows at once.
+func (m model) errorRows() int {
+	_, h := m.size()
+	return maxInt(h-8, 5)
+}
+
 // ── Section Header ──
 // Renders an uppercase header with colored left border glow effect
-func sectionHeader(title string, count int, accent lipgloss.Color, focused bool) string {
+func (t Theme) sectionHeader(title string, count int, accent lipgloss.TerminalColor, focused bool) string {
 	borderChar := "┃"
 	glowChar := "░"
 
 	borderColor := accent
 	if !focused {
-		borderColor = lipgloss.Color("#333333")
+		borderColor = t.Dimmer
 	}
 
 	border := lipgloss.NewStyle().Foreground(borderColor).Render(borderChar)
@@ -424,12 +1387,12 @@ func sectionHeader(title string, count int, accent lipgloss.Color, focused bool)
 		Bold(true)
 
 	if !focused {
-		titleStyle = titleStyle.Foreground(colorDim)
+		titleStyle = titleStyle.Foreground(t.Dim)
 	}
 
 	countStr := lipgloss.NewStyle().Foreground(accent).Render(fmt.Sprintf(" %d", count))
 	if !focused {
-		countStr = lipgloss.NewStyle().Foreground(colorDim).Render(fmt.Sprintf(" %d", count))
+		countStr = lipgloss.NewStyle().Foreground(t.Dim).Render(fmt.Sprintf(" %d", count))
 	}
 
 	return border + glow + " " + titleStyle.Render(title) + countStr
@@ -438,33 +1401,39 @@ func sectionHeader(title string, count int, accent lipgloss.Color, focused bool)
 // ── Left Panel: Active + Idle ──
 func (m model) renderLeftPanel(active, idle []api.Session, w, maxRows int) []string {
 	var lines []string
-	activeFocused := m.section == sectionActive
-	idleFocused := m.section == sectionIdle
+	activeFocused := m.section == sectionActive && !m.snapshot
+	idleFocused := m.section == sectionIdle && !m.snapshot
+
+	rows := m.sectionRows()
 
 	// Active header
-	lines = append(lines, sectionHeader("● ACTIVE SESSIONS", len(active), colorGreen, activeFocused))
+	from, to := m.visibleRange(sectionActive, rows[sectionActive])
+	lines = append(lines, m.theme.sectionHeader("● ACTIVE SESSIONS", len(active), m.theme.Green, activeFocused)+m.theme.scrollHint(from, len(active)-to))
 
 	if len(active) == 0 {
-		lines = append(lines, renderBorderedLine("    "+lipgloss.NewStyle().Foreground(colorDim).Render("No active sessions"), colorGreen, activeFocused))
+		lines = append(lines, m.theme.renderBorderedLine("    "+lipgloss.NewStyle().Foreground(m.theme.Dim).Render("No active sessions"), m.theme.Green, activeFocused))
 	} else {
-		for i, s := range active {
+		for i := from; i < to; i++ {
+			s := active[i]
 			selected := activeFocused && m.sectionCur[sectionActive] == i
-			lines = append(lines, m.renderSessionRow(s, w, selected, activeFocused, colorGreen))
+			lines = append(lines, m.renderSessionRow(s, w, selected, activeFocused, m.theme.Green))
 		}
 	}
 
 	lines = append(lines, "")
 
 	// Idle header
-	lines = append(lines, sectionHeader("○ IDLE", len(idle), lipgloss.Color("#666666"), idleFocused))
+	from, to = m.visibleRange(sectionIdle, rows[sectionIdle])
+	lines = append(lines, m.theme.sectionHeader("○ IDLE", len(idle), m.theme.Idle, idleFocused)+m.theme.scrollHint(from, len(idle)-to))
 
 	if len(idle) == 0 {
-		lines = append(lines, renderBorderedLine("    "+lipgloss.NewStyle().Foreground(colorDim).Render("No idle sessions"), lipgloss.Color("#666666"), idleFocused))
+		lines = append(lines, m.theme.renderBorderedLine("    "+lipgloss.NewStyle().Foreground(m.theme.Dim).Render("No idle sessions"), m.theme.Idle, idleFocused))
 	} else {
-		for i, s := range idle {
+		for i := from; i < to; i++ {
+			s := idle[i]
 			selected := idleFocused && m.sectionCur[sectionIdle] == i
 			if selected {
-				lines = append(lines, m.renderSessionRow(s, w, true, true, lipgloss.Color("#666666")))
+				lines = append(lines, m.renderSessionRow(s, w, true, true, m.theme.Idle))
 			} else {
 				lines = append(lines, m.renderSessionRowDim(s, w, idleFocused))
 			}
@@ -475,10 +1444,10 @@ func (m model) renderLeftPanel(active, idle []api.Session, w, maxRows int) []str
 }
 
 // renderBorderedLine renders a line with left border accent
-func renderBorderedLine(content string, accent lipgloss.Color, focused bool) string {
+func (t Theme) renderBorderedLine(content string, accent lipgloss.TerminalColor, focused bool) string {
 	borderColor := accent
 	if !focused {
-		borderColor = lipgloss.Color("#333333")
+		borderColor = t.Dimmer
 	}
 	border := lipgloss.NewStyle().Foreground(borderColor).Render("┃")
 	return border + " " + content
@@ -487,32 +1456,38 @@ func renderBorderedLine(content string, accent lipgloss.Color, focused bool) str
 // ── Right Panel: Sub-agents + Cron ──
 func (m model) renderRightPanel(subs, crons []api.Session, w, maxRows int) []string {
 	var lines []string
-	subsFocused := m.section == sectionSubs
-	cronsFocused := m.section == sectionCrons
+	subsFocused := m.section == sectionSubs && !m.snapshot
+	cronsFocused := m.section == sectionCrons && !m.snapshot
+
+	rows := m.sectionRows()
 
 	// Sub-agents header
-	lines = append(lines, sectionHeader("⚡ SUB-AGENTS", len(subs), colorPurple, subsFocused))
+	from, to := m.visibleRange(sectionSubs, rows[sectionSubs])
+	lines = append(lines, m.theme.sectionHeader("⚡ SUB-AGENTS", len(subs), m.theme.Purple, subsFocused)+m.theme.scrollHint(from, len(subs)-to))
 
 	if len(subs) == 0 {
-		lines = append(lines, renderBorderedLine("   "+lipgloss.NewStyle().Foreground(colorDim).Render("None"), colorPurple, subsFocused))
+		lines = append(lines, m.theme.renderBorderedLine("   "+lipgloss.NewStyle().Foreground(m.theme.Dim).Render("None"), m.theme.Purple, subsFocused))
 	} else {
-		for i, s := range subs {
+		for i := from; i < to; i++ {
+			s := subs[i]
 			selected := subsFocused && m.sectionCur[sectionSubs] == i
-			lines = append(lines, m.renderCard(s, w, colorPurple, selected, subsFocused))
+			lines = append(lines, m.renderCard(s, w, m.theme.Purple, selected, subsFocused))
 		}
 	}
 
 	lines = append(lines, "")
 
 	// Cron header
-	lines = append(lines, sectionHeader("⏱  CRON JOBS", len(crons), colorOrange, cronsFocused))
+	from, to = m.visibleRange(sectionCrons, rows[sectionCrons])
+	lines = append(lines, m.theme.sectionHeader("⏱  CRON JOBS", len(crons), m.theme.Orange, cronsFocused)+m.theme.scrollHint(from, len(crons)-to))
 
 	if len(crons) == 0 {
-		lines = append(lines, renderBorderedLine("   "+lipgloss.NewStyle().Foreground(colorDim).Render("None"), colorOrange, cronsFocused))
+		lines = append(lines, m.theme.renderBorderedLine("   "+lipgloss.NewStyle().Foreground(m.theme.Dim).Render("None"), m.theme.Orange, cronsFocused))
 	} else {
-		for i, s := range crons {
+		for i := from; i < to; i++ {
+			s := crons[i]
 			selected := cronsFocused && m.sectionCur[sectionCrons] == i
-			lines = append(lines, m.renderCard(s, w, colorOrange, selected, cronsFocused))
+			lines = append(lines, m.renderCard(s, w, m.theme.Orange, selected, cronsFocused))
 		}
 	}
 
@@ -520,9 +1495,19 @@ func (m model) renderRightPanel(subs, crons []api.Session, w, maxRows int) []str
 }
 
 // ── Activity Chart ──
+
+// chartLabelW is the width of the bar chart's y-axis labels.
+const chartLabelW = 6
+
 func (m model) renderActivityChart(w int) string {
-	headerStyle := lipgloss.NewStyle().Foreground(colorDim).Bold(true)
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Dim).Bold(true)
 	header := headerStyle.Render("  ▌ 24H ACTIVITY")
+	if i := m.chartHover; i >= 0 && i < len(m.activity) {
+		b := m.activity[i]
+		header += lipgloss.NewStyle().Foreground(m.theme.Fg).Render("  "+b.Start.Format("15:04")+"  ") +
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render(fmt.Sprintf("%d messages", b.Messages)) +
+			lipgloss.NewStyle().Foreground(m.theme.Purple).Render(fmt.Sprintf("  $%.2f", b.Cost))
+	}
 	return header + "\n" + m.renderBarChart(w)
 }
 
@@ -530,12 +1515,12 @@ func (m model) renderBarChart(w int) string {
 	chartHeight := 8
 	blocks := []rune(" ▁▂▃▄▅▆▇█")
 
-	data := m.hourly
+	data := m.activity
 	if len(data) == 0 {
-		return lipgloss.NewStyle().Foreground(colorDim).Render("  no activity data\n")
+		return lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  no activity data\n")
 	}
 
-	yLabelW := 6
+	yLabelW := chartLabelW
 	barAreaW := w - yLabelW - 1
 	if barAreaW < 10 {
 		barAreaW = 10
@@ -582,10 +1567,10 @@ func (m model) renderBarChart(w int) string {
 	midLabel := topLabel / 2
 
 	var sb strings.Builder
-	dimStyle := lipgloss.NewStyle().Foreground(colorDimmer)
-	barStyle := lipgloss.NewStyle().Foreground(colorGreen)
-	costStyle := lipgloss.NewStyle().Foreground(colorPurple)
-	zeroStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#0a3a1a"))
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.Dimmer)
+	barStyle := lipgloss.NewStyle().Foreground(m.theme.Green)
+	costStyle := lipgloss.NewStyle().Foreground(m.theme.Purple)
+	zeroStyle := lipgloss.NewStyle().Foreground(m.theme.ChartZero)
 
 	for row := chartHeight; row >= 1; row-- {
 		// Y-axis label
@@ -646,8 +1631,7 @@ func (m model) renderBarChart(w int) string {
 		step = 4
 	}
 	for i := 0; i < numBuckets; i++ {
-		hourNum := 0
-		fmt.Sscanf(data[i].Hour, "%d", &hourNum)
+		hourNum := data[i].Start.Hour()
 		if hourNum%step == 0 {
 			col := i * barAreaW / numBuckets
 			lbl := fmt.Sprintf("%02d", hourNum)
@@ -665,13 +1649,13 @@ func (m model) renderBarChart(w int) string {
 }
 
 // ── Session Row ──
-func (m model) renderSessionRow(s api.Session, w int, selected bool, sectionFocused bool, accent lipgloss.Color) string {
+func (m model) renderSessionRow(s api.Session, w int, selected bool, sectionFocused bool, accent lipgloss.TerminalColor) string {
 	nameW := clampInt(w*25/100, 12, 35)
 	modelW := clampInt(w*15/100, 8, 22)
 
-	dot := lipgloss.NewStyle().Foreground(colorGreen).Render("●")
+	dot := lipgloss.NewStyle().Foreground(m.theme.Green).Render("●")
 	if !s.IsActive {
-		dot = lipgloss.NewStyle().Foreground(colorDim).Render("○")
+		dot = lipgloss.NewStyle().Foreground(m.theme.Dim).Render("○")
 	}
 
 	name := truncate(s.Name, nameW)
@@ -693,56 +1677,61 @@ func (m model) renderSessionRow(s api.Session, w int, selected bool, sectionFocu
 	// Border glow
 	borderColor := accent
 	if !sectionFocused {
-		borderColor = lipgloss.Color("#333333")
+		borderColor = m.theme.Dimmer
 	}
 	border := lipgloss.NewStyle().Foreground(borderColor).Render("┃")
 
-	nameColor := colorWhite
+	nameColor := m.theme.Bright
 	if !sectionFocused {
-		nameColor = colorFg
+		nameColor = m.theme.Fg
 	}
 
 	var line string
-	if w >= 110 {
+	if cols := m.rowColumns(); len(cols) > 0 {
+		line = fmt.Sprintf("%s%s %s %s %s",
+			border, cursor,
+			dot,
+			lipgloss.NewStyle().Foreground(nameColor).Render(name),
+			m.theme.renderColumns(s, cols, w-nameW-6, false),
+		)
+	} else if w >= 110 {
 		line = fmt.Sprintf("%s%s %s %s %s %s %s %s  %s",
 			border, cursor,
 			dot,
 			lipgloss.NewStyle().Foreground(nameColor).Render(name),
-			lipgloss.NewStyle().Foreground(colorDim).Render(mdl),
-			lipgloss.NewStyle().Foreground(colorFg).Render(msgs),
-			lipgloss.NewStyle().Foreground(colorGreen).Render(fmt.Sprintf("%7s", today)),
-			lipgloss.NewStyle().Foreground(colorDim).Render(fmt.Sprintf("%7s", total)),
-			lipgloss.NewStyle().Foreground(colorDimmer).Render(ago),
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(mdl),
+			lipgloss.NewStyle().Foreground(m.theme.Fg).Render(msgs),
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render(fmt.Sprintf("%7s", today)),
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf("%7s", total)),
+			lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render(ago),
 		)
 	} else if w >= 70 {
 		line = fmt.Sprintf("%s%s %s %s %s %s  %s",
 			border, cursor,
 			dot,
 			lipgloss.NewStyle().Foreground(nameColor).Render(name),
-			lipgloss.NewStyle().Foreground(colorFg).Render(msgs),
-			lipgloss.NewStyle().Foreground(colorGreen).Render(fmt.Sprintf("%7s", today)),
-			lipgloss.NewStyle().Foreground(colorDimmer).Render(ago),
+			lipgloss.NewStyle().Foreground(m.theme.Fg).Render(msgs),
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render(fmt.Sprintf("%7s", today)),
+			lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render(ago),
 		)
 	} else {
 		line = fmt.Sprintf("%s%s %s %s %s",
 			border, cursor,
 			dot,
 			lipgloss.NewStyle().Foreground(nameColor).Render(truncate(s.Name, 18)),
-			lipgloss.NewStyle().Foreground(colorGreen).Render(today),
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render(today),
 		)
 	}
 
+	if badge := m.badges(s); badge != "" {
+		line += "  " + badge
+	}
+
 	if selected {
-		// Highlight: use colored background
-		lineContent := padRight(line, w)
-		lineContent = lipgloss.NewStyle().
-			Background(colorSelectBg).
-			Bold(true).
-			Render(lineContent)
-		return lineContent
+		return m.theme.selected().Render(padRight(line, w))
 	}
 
-	return line
+	return m.highlighted(s, line, w)
 }
 
 func (m model) renderSessionRowDim(s api.Session, w int, sectionFocused bool) string {
@@ -751,42 +1740,183 @@ func (m model) renderSessionRowDim(s api.Session, w int, sectionFocused bool) st
 	name := truncate(s.Name, nameW)
 	name = fmt.Sprintf("%-*s", nameW, name)
 
-	msgs := fmt.Sprintf("%3d", s.MessageCount)
-	total := fmt.Sprintf("$%.2f", s.TotalCost)
-	ago := timeAgo(s.UpdatedAt)
+	msgs := fmt.Sprintf("%3d", s.MessageCount)
+	total := fmt.Sprintf("$%.2f", s.TotalCost)
+	ago := timeAgo(s.UpdatedAt)
+
+	dim := lipgloss.NewStyle().Foreground(m.theme.Dimmer)
+	dimFg := lipgloss.NewStyle().Foreground(m.theme.Dim)
+
+	borderColor := m.theme.CardBorder
+	if sectionFocused {
+		borderColor = m.theme.CardBorderFocus
+	}
+	border := lipgloss.NewStyle().Foreground(borderColor).Render("┃")
+
+	badge := m.badges(s)
+	if badge != "" {
+		badge = "  " + badge
+	}
+
+	if cols := m.rowColumns(); len(cols) > 0 {
+		return m.highlighted(s, fmt.Sprintf("%s   %s %s %s%s",
+			border,
+			dim.Render("○"),
+			dimFg.Render(name),
+			m.theme.renderColumns(s, cols, w-nameW-6, true),
+			badge,
+		), w)
+	}
+	if w >= 70 {
+		return m.highlighted(s, fmt.Sprintf("%s   %s %s %s %s  %s%s",
+			border,
+			dim.Render("○"),
+			dimFg.Render(name),
+			dim.Render(msgs),
+			dim.Render(fmt.Sprintf("%7s", total)),
+			dim.Render(ago),
+			badge,
+		), w)
+	}
+	return m.highlighted(s, fmt.Sprintf("%s   %s %s %s%s",
+		border,
+		dim.Render("○"),
+		dimFg.Render(truncate(s.Name, 18)),
+		dim.Render(total),
+		badge,
+	), w)
+}
+
+// ── Sorting & Columns ──
+
+// sortKey orders sessions. less sorts ascending; desc means the natural
+// order is the reverse, largest first.
+type sortKey struct {
+	name string
+	desc bool
+	less func(a, b api.Session) bool
+}
+
+// sortKeys are cycled with s. The first, most recently updated first, is
+// the default.
+var sortKeys = []sortKey{
+	{"age", false, func(a, b api.Session) bool { return a.UpdatedAt > b.UpdatedAt }},
+	{"today", true, func(a, b api.Session) bool { return a.TodayCost < b.TodayCost }},
+	{"cost", true, func(a, b api.Session) bool { return a.TotalCost < b.TotalCost }},
+	{"messages", true, func(a, b api.Session) bool { return a.MessageCount < b.MessageCount }},
+	{"tokens", true, func(a, b api.Session) bool { return a.TotalTokens < b.TotalTokens }},
+	{"name", false, func(a, b api.Session) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }},
+}
+
+// sorted returns a copy of sessions in the current sort order, pinned
+// sessions first.
+func (m model) sorted(sessions []api.Session) []api.Session {
+	key := sortKeys[m.sortBy]
+	desc := key.desc != m.sortReverse
+	out := append([]api.Session(nil), sessions...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		if desc {
+			return key.less(b, a)
+		}
+		return key.less(a, b)
+	})
+	return out
+}
+
+// sortLabel describes the sort order, e.g. "sort:cost↓".
+func (m model) sortLabel() string {
+	key := sortKeys[m.sortBy]
+	arrow := "↑"
+	if key.desc != m.sortReverse {
+		arrow = "↓"
+	}
+	return "sort:" + key.name + arrow
+}
+
+// rowColumn is an optional session row column.
+type rowColumn struct {
+	width int
+	right bool   // right-align
+	color string // Theme color key
+	value func(api.Session) string
+}
+
+var rowColumnDefs = map[string]rowColumn{
+	"model":    {18, false, "dim", func(s api.Session) string { return modelDisplay(s.Model) }},
+	"agent":    {10, false, "dim", func(s api.Session) string { return s.Agent }},
+	"kind":     {8, false, "dim", func(s api.Session) string { return s.Kind }},
+	"messages": {4, true, "fg", func(s api.Session) string { return strconv.Itoa(s.MessageCount) }},
+	"tokens":   {6, true, "fg", func(s api.Session) string { return formatTokens(s.TotalTokens) }},
+	"context":  {4, true, "fg", contextPercent},
+	"errors":   {3, true, "red", func(s api.Session) string { return strconv.Itoa(s.ErrorCount) }},
+	"today":    {7, true, "green", func(s api.Session) string { return fmt.Sprintf("$%.2f", s.TodayCost) }},
+	"total":    {7, true, "dim", func(s api.Session) string { return fmt.Sprintf("$%.2f", s.TotalCost) }},
+	"age":      {8, false, "dimmer", func(s api.Session) string { return timeAgo(s.UpdatedAt) }},
+}
+
+func contextPercent(s api.Session) string {
+	if s.ContextLimit == 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.0f%%", s.ContextUtilization*100)
+}
+
+// columnNames lists rowColumnDefs in the order the chooser shows them.
+var columnNames = []string{"model", "agent", "kind", "messages", "tokens", "context", "errors", "today", "total", "age"}
 
-	dim := lipgloss.NewStyle().Foreground(colorDimmer)
-	dimFg := lipgloss.NewStyle().Foreground(colorDim)
+// rowColumns returns the configured columns, or nil to choose by width.
+func (m model) rowColumns() []string {
+	if m.columns != nil {
+		return m.columns
+	}
+	return m.configColumns
+}
 
-	borderColor := lipgloss.Color("#222222")
-	if sectionFocused {
-		borderColor = lipgloss.Color("#444444")
+// unknownColumns returns the names in cols that are not columns.
+func unknownColumns(cols []string) []string {
+	var unknown []string
+	for _, c := range cols {
+		if _, ok := rowColumnDefs[c]; !ok {
+			unknown = append(unknown, c)
+		}
 	}
-	border := lipgloss.NewStyle().Foreground(borderColor).Render("┃")
+	return unknown
+}
 
-	if w >= 70 {
-		return fmt.Sprintf("%s   %s %s %s %s  %s",
-			border,
-			dim.Render("○"),
-			dimFg.Render(name),
-			dim.Render(msgs),
-			dim.Render(fmt.Sprintf("%7s", total)),
-			dim.Render(ago),
-		)
+// renderColumns renders cols for s, dropping those that do not fit in w.
+func (t Theme) renderColumns(s api.Session, cols []string, w int, dim bool) string {
+	var parts []string
+	used := 0
+	for _, name := range cols {
+		col := rowColumnDefs[name]
+		if used+col.width+1 > w {
+			break
+		}
+		text := truncate(col.value(s), col.width)
+		if col.right {
+			text = fmt.Sprintf("%*s", col.width, text)
+		} else {
+			text = fmt.Sprintf("%-*s", col.width, text)
+		}
+		color := *t.colors()[col.color]
+		if dim {
+			color = t.Dimmer
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(color).Render(text))
+		used += col.width + 1
 	}
-	return fmt.Sprintf("%s   %s %s %s",
-		border,
-		dim.Render("○"),
-		dimFg.Render(truncate(s.Name, 18)),
-		dim.Render(total),
-	)
+	return strings.Join(parts, " ")
 }
 
 // ── Card (Sub-agent / Cron) ──
-func (m model) renderCard(s api.Session, w int, accent lipgloss.Color, selected bool, sectionFocused bool) string {
+func (m model) renderCard(s api.Session, w int, accent lipgloss.TerminalColor, selected bool, sectionFocused bool) string {
 	borderColor := accent
 	if !sectionFocused {
-		borderColor = lipgloss.Color("#333333")
+		borderColor = m.theme.Dimmer
 	}
 	border := lipgloss.NewStyle().Foreground(borderColor).Render("┃")
 
@@ -795,7 +1925,7 @@ func (m model) renderCard(s api.Session, w int, accent lipgloss.Color, selected
 
 	activeDot := ""
 	if s.IsActive {
-		activeDot = " " + lipgloss.NewStyle().Foreground(colorGreen).Render("●")
+		activeDot = " " + lipgloss.NewStyle().Foreground(m.theme.Green).Render("●")
 	}
 
 	cursor := "  "
@@ -803,17 +1933,17 @@ func (m model) renderCard(s api.Session, w int, accent lipgloss.Color, selected
 		cursor = " ▸"
 	}
 
-	nameColor := colorFg
+	nameColor := m.theme.Fg
 	if selected {
-		nameColor = colorWhite
+		nameColor = m.theme.Bright
 	} else if !sectionFocused {
-		nameColor = colorDim
+		nameColor = m.theme.Dim
 	}
 
-	meta := lipgloss.NewStyle().Foreground(colorDim).Render(
+	meta := lipgloss.NewStyle().Foreground(m.theme.Dim).Render(
 		fmt.Sprintf("%d msgs", s.MessageCount)) +
 		"  " +
-		lipgloss.NewStyle().Foreground(colorGreen).Render(
+		lipgloss.NewStyle().Foreground(m.theme.Green).Render(
 			fmt.Sprintf("$%.2f", s.TodayCost))
 
 	line := fmt.Sprintf("%s%s %s%s  %s",
@@ -822,110 +1952,726 @@ func (m model) renderCard(s api.Session, w int, accent lipgloss.Color, selected
 		activeDot,
 		meta,
 	)
+	if badge := m.badges(s); badge != "" {
+		line += "  " + badge
+	}
 
 	if selected {
-		line = lipgloss.NewStyle().
-			Background(colorSelectBg).
-			Bold(true).
-			Render(padRight(line, w))
+		return m.theme.selected().Render(padRight(line, w))
 	}
 
-	return line
+	return m.highlighted(s, line, w)
 }
 
 // ── Detail View ──
 func (m model) renderDetail(w, h int) string {
 	s, ok := m.selectedSession()
 	if !ok {
-		return lipgloss.NewStyle().Foreground(colorDim).Render("  No session selected\n")
+		return lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  No session selected\n")
 	}
 
 	cardW := clampInt(w-4, 40, 100)
 
 	border := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(colorBorder).
+		BorderForeground(m.theme.Border).
 		Padding(1, 2).
 		Width(cardW)
 
 	var status string
-	if s.IsActive {
-		status = lipgloss.NewStyle().Foreground(colorGreen).Bold(true).Render("● Active")
+	if s.Stuck {
+		status = lipgloss.NewStyle().Foreground(m.theme.Orange).Bold(true).Render("◌ Stuck") +
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(
+				"  "+s.StuckReason+", no progress for "+strings.TrimSuffix(timeAgo(s.LastProgressAt), " ago"))
+	} else if s.IsActive {
+		status = lipgloss.NewStyle().Foreground(m.theme.Green).Bold(true).Render("● Active")
 	} else {
-		status = lipgloss.NewStyle().Foreground(colorDim).Render("○ Inactive")
+		status = lipgloss.NewStyle().Foreground(m.theme.Dim).Render("○ Inactive")
 	}
 
-	kindColor := colorGreen
+	kindColor := m.theme.Green
 	switch s.Kind {
 	case "cron":
-		kindColor = colorOrange
+		kindColor = m.theme.Orange
 	case "subagent":
-		kindColor = colorPurple
+		kindColor = m.theme.Purple
 	}
 
-	labelStyle := lipgloss.NewStyle().Foreground(colorDim).Width(12)
-	valStyle := lipgloss.NewStyle().Foreground(colorFg)
+	labelStyle := lipgloss.NewStyle().Foreground(m.theme.Dim).Width(12)
+	valStyle := lipgloss.NewStyle().Foreground(m.theme.Fg)
 
-	content := strings.Join([]string{
-		lipgloss.NewStyle().Bold(true).Foreground(colorWhite).Render(s.Name),
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(m.theme.Bright).Render(s.Name),
 		"",
 		labelStyle.Render("Status") + "  " + status,
 		labelStyle.Render("Kind") + "  " + lipgloss.NewStyle().Foreground(kindColor).Render(s.Kind),
 		labelStyle.Render("Model") + "  " + valStyle.Render(modelDisplay(s.Model)),
 		labelStyle.Render("Messages") + "  " + valStyle.Render(fmt.Sprintf("%d", s.MessageCount)),
-		labelStyle.Render("Today") + "  " + lipgloss.NewStyle().Foreground(colorGreen).Render(fmt.Sprintf("$%.4f", s.TodayCost)),
+		labelStyle.Render("Today") + "  " + lipgloss.NewStyle().Foreground(m.theme.Green).Render(fmt.Sprintf("$%.4f", s.TodayCost)),
 		labelStyle.Render("Total") + "  " + valStyle.Render(fmt.Sprintf("$%.4f", s.TotalCost)),
 		labelStyle.Render("Updated") + "  " + valStyle.Render(
-			time.UnixMilli(s.UpdatedAt).Format("2006-01-02 15:04:05")+
+			m.client.In(time.UnixMilli(s.UpdatedAt)).Format("2006-01-02 15:04:05")+
 				" ("+timeAgo(s.UpdatedAt)+")"),
-		labelStyle.Render("Session") + "  " + lipgloss.NewStyle().Foreground(colorDimmer).Render(s.SessionID),
-		"",
-		lipgloss.NewStyle().Bold(true).Foreground(colorDim).Render("24H ACTIVITY"),
-		m.renderSparkline(clampInt(cardW-6, 20, 80)),
-	}, "\n")
+		labelStyle.Render("Session") + "  " + lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render(s.SessionID),
+	}
+
+	if s.OriginalName != "" {
+		lines = append(lines, labelStyle.Render("OpenClaw")+"  "+lipgloss.NewStyle().Foreground(m.theme.Dim).Render(s.OriginalName))
+	}
+	if len(s.Tags) > 0 || s.Pinned || s.Hidden {
+		flags := m.theme.tagBadges(s)
+		if s.Pinned {
+			flags += lipgloss.NewStyle().Foreground(m.theme.Orange).Render("  ★ pinned")
+		}
+		if s.Hidden {
+			flags += lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  hidden")
+		}
+		lines = append(lines, labelStyle.Render("Tags")+"  "+strings.TrimSpace(flags))
+	}
+	if s.Note != "" {
+		lines = append(lines, labelStyle.Render("Note")+"  "+valStyle.Render(truncate(s.Note, cardW-20)))
+	}
+
+	if s.LastError != nil {
+		lines = append(lines, labelStyle.Render("Errors")+"  "+
+			lipgloss.NewStyle().Foreground(m.theme.Red).Render(fmt.Sprintf("%d", s.ErrorCount))+
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf("  last %s %s: ", s.LastError.Kind, timeAgo(s.LastError.At)))+
+			valStyle.Render(truncate(s.LastError.Message, cardW-40)))
+	}
+
+	if s.ContextLimit > 0 || s.ContextTokens > 0 {
+		lines = append(lines, labelStyle.Render("Context")+"  "+m.theme.renderContextGauge(s, clampInt(cardW-60, 10, 30)))
+		if len(s.ContextHistory) > 1 {
+			lines = append(lines, labelStyle.Render("")+"  "+m.theme.renderContextHistory(s.ContextHistory, clampInt(cardW-20, 10, 60)))
+		}
+	}
+
+	if s.Latency != nil {
+		lat := s.Latency
+		lines = append(lines,
+			labelStyle.Render("Latency")+"  "+valStyle.Render(formatPercentiles(lat.TurnLatency, formatMillis)),
+			labelStyle.Render("Tool time")+"  "+valStyle.Render(formatPercentiles(lat.ToolTime, formatMillis)),
+			labelStyle.Render("Throughput")+"  "+valStyle.Render(formatPercentiles(lat.TokensPerSec, formatTokensPerSec)),
+		)
+	}
+
+	if len(s.Anomalies) > 0 {
+		lines = append(lines, "", lipgloss.NewStyle().Bold(true).Foreground(m.theme.Red).Render("ANOMALIES"))
+		for _, a := range s.Anomalies {
+			lines = append(lines,
+				lipgloss.NewStyle().Foreground(m.theme.Red).Render("⚠ "+a.Kind)+"  "+
+					valStyle.Render(a.Message)+"  "+
+					lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render(timeAgo(a.At)))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.renderTimeline(clampInt(cardW-14, 20, 80))...)
+	content := strings.Join(lines, "\n")
 
 	card := border.Render(content)
 
 	return "\n" + lipgloss.NewStyle().Width(w).Align(lipgloss.Center).Render(card) + "\n\n" +
-		lipgloss.NewStyle().Foreground(colorDim).Render("  esc back  r refresh  q quit")
+		m.renderFooter(viewDetail, w)
+}
+
+// ── Tail View ──
+
+// startTail opens follow mode on the selected session, starting with its
+// transcript so far.
+func (m *model) startTail() tea.Cmd {
+	s, ok := m.selectedSession()
+	if !ok {
+		return nil
+	}
+	m.tail = m.client.NewTailer(s.SessionID)
+	m.tailName = s.Name
+	m.tailEntries, m.tailOffset, m.tailPaused, m.tailNew = nil, 0, false, 0
+	m.tailGen++
+	m.view = viewTail
+	m.readTail()
+	gen := m.tailGen
+	return tea.Tick(tailInterval, func(time.Time) tea.Msg { return tailTickMsg(gen) })
+}
+
+// readTail appends whatever was written to the transcript since the last
+// read.
+func (m *model) readTail() {
+	entries, restarted, err := m.tail.Read()
+	m.tailErr = err
+	if restarted {
+		m.tailEntries, m.tailOffset = nil, 0
+	}
+	if m.tailPaused {
+		m.tailNew += len(entries)
+	}
+	m.tailEntries = append(m.tailEntries, entries...)
+	if over := len(m.tailEntries) - maxTailEntries; over > 0 {
+		m.tailEntries = append([]api.TailEntry(nil), m.tailEntries[over:]...)
+		m.tailOffset = maxInt(m.tailOffset-over, 0)
+	}
+}
+
+// tailRows is how many entries the tail view shows at once.
+func (m model) tailRows() int {
+	_, h := m.size()
+	return maxInt(h-7, 5)
+}
+
+// tailFrom returns the first entry to draw: the bottom while following,
+// the scroll position while paused.
+func (m model) tailFrom() int {
+	bottom := maxInt(len(m.tailEntries)-m.tailRows(), 0)
+	if !m.tailPaused {
+		return bottom
+	}
+	return minInt(m.tailOffset, bottom)
+}
+
+// updateTail handles an action in the tail view, reporting whether it did.
+// Scrolling up pauses following; scrolling back to the bottom resumes it.
+func (m *model) updateTail(action string) bool {
+	rows := m.tailRows()
+	bottom := maxInt(len(m.tailEntries)-rows, 0)
+	m.tailOffset = m.tailFrom()
+	scroll := func(delta int) {
+		m.tailOffset = clampInt(m.tailOffset+delta, 0, bottom)
+		m.tailPaused = m.tailOffset < bottom
+		if !m.tailPaused {
+			m.tailNew = 0
+		}
+	}
+	switch action {
+	case "down":
+		scroll(1)
+	case "up":
+		scroll(-1)
+	case "pageDown":
+		scroll(rows)
+	case "pageUp":
+		scroll(-rows)
+	case "top":
+		scroll(-len(m.tailEntries))
+	case "bottom":
+		scroll(len(m.tailEntries))
+	case "pause":
+		if m.tailPaused {
+			scroll(len(m.tailEntries))
+		} else {
+			m.tailPaused = true
+		}
+	case "back", "quit", "follow":
+		m.view = viewDetail
+		m.tailGen++
+	default:
+		return false
+	}
+	return true
+}
+
+func (m model) renderTail(w int) string {
+	var b strings.Builder
+	dim := lipgloss.NewStyle().Foreground(m.theme.Dim)
+
+	state := lipgloss.NewStyle().Foreground(m.theme.Green).Render("● following")
+	if m.tailPaused {
+		state = lipgloss.NewStyle().Foreground(m.theme.Orange).Render("❚❚ paused")
+		if m.tailNew > 0 {
+			state += lipgloss.NewStyle().Foreground(m.theme.Orange).Render(fmt.Sprintf(" · %d new", m.tailNew))
+		}
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Green).Bold(true).Render("  ▌ LIVE ") +
+		lipgloss.NewStyle().Foreground(m.theme.Bright).Render(m.tailName) + "  " + state)
+	b.WriteString("\n\n")
+
+	if m.tailErr != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Red).Render("  " + m.tailErr.Error()))
+		b.WriteString("\n")
+	} else if len(m.tailEntries) == 0 {
+		b.WriteString(dim.Render("  Waiting for transcript entries…"))
+		b.WriteString("\n")
+	}
+
+	from := m.tailFrom()
+	to := minInt(from+m.tailRows(), len(m.tailEntries))
+	for _, e := range m.tailEntries[from:to] {
+		b.WriteString(m.renderTailEntry(e, w))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(viewTail, w))
+	return b.String()
+}
+
+// renderTailEntry draws one tail entry on one line: time, a colored label,
+// the text with whitespace collapsed, and the cost of assistant turns.
+func (m model) renderTailEntry(e api.TailEntry, w int) string {
+	t := m.theme
+	labelW := 14
+	label, color := e.Kind, t.Fg
+	switch e.Kind {
+	case api.TailUser:
+		label, color = "user", t.Cyan
+	case api.TailAssistant:
+		label, color = "assistant", t.Green
+	case api.TailToolCall:
+		label, color = "→ "+e.Tool, t.Purple
+	case api.TailToolResult:
+		label, color = "← "+e.Tool, t.Dim
+		if e.Failed {
+			label, color = "✗ "+e.Tool, t.Red
+		}
+	case api.TailError:
+		label, color = "error", t.Red
+	case api.TailCompaction:
+		label, color = "compaction", t.Orange
+	}
+
+	cost := ""
+	if e.Cost > 0 {
+		cost = fmt.Sprintf("  $%.4f", e.Cost)
+	}
+	textW := maxInt(w-2-8-2-labelW-1-lipgloss.Width(cost), 10)
+	text := strings.Join(strings.Fields(e.Text), " ")
+	textColor := t.Fg
+	if e.Kind == api.TailToolCall || e.Kind == api.TailToolResult {
+		textColor = t.Dim
+	}
+
+	return "  " + lipgloss.NewStyle().Foreground(t.Dimmer).Render(m.client.In(time.UnixMilli(e.At)).Format("15:04:05")) + "  " +
+		lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%-*s", labelW, truncate(label, labelW))) + " " +
+		lipgloss.NewStyle().Foreground(textColor).Render(truncate(text, textW)) +
+		lipgloss.NewStyle().Foreground(t.Purple).Render(cost)
 }
 
-func (m model) renderSparkline(width int) string {
-	if len(m.hourly) == 0 {
-		return lipgloss.NewStyle().Foreground(colorDim).Render("no data")
+// ── Errors View ──
+func (m model) renderErrors(w, h int) string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Red).Bold(true)
+	b.WriteString(headerStyle.Render("  ▌ ERRORS") +
+		lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf(" %d", len(m.errors))))
+	b.WriteString("\n\n")
+
+	if !m.viewLoaded {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  Loading…"))
+		b.WriteString("\n")
+	} else if len(m.errors) == 0 {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  No errors found in any transcript"))
+		b.WriteString("\n")
+	}
+
+	timeW := 15
+	sessW := clampInt(w*20/100, 12, 30)
+	modelW := clampInt(w*15/100, 8, 22)
+	kindW := 11
+	msgW := w - timeW - sessW - modelW - kindW - 10
+	if msgW < 10 {
+		msgW = 10
+	}
+
+	kindColors := map[string]lipgloss.TerminalColor{
+		api.ErrorRateLimit:  m.theme.Orange,
+		api.ErrorOverloaded: m.theme.Purple,
+		api.ErrorAborted:    m.theme.Dim,
+		api.ErrorTool:       m.theme.Cyan,
+	}
+
+	rows := m.errorRows()
+	for i := m.errorsOffset; i < len(m.errors) && i < m.errorsOffset+rows; i++ {
+		e := m.errors[i]
+		kindColor, ok := kindColors[e.Kind]
+		if !ok {
+			kindColor = m.theme.Red
+		}
+		b.WriteString(fmt.Sprintf("  %s %s %s %s %s\n",
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf("%-*s", timeW, m.client.In(time.UnixMilli(e.At)).Format("Jan 2 15:04:05"))),
+			lipgloss.NewStyle().Foreground(m.theme.Bright).Render(fmt.Sprintf("%-*s", sessW, truncate(e.SessionName, sessW))),
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(fmt.Sprintf("%-*s", modelW, truncate(modelDisplay(e.Model), modelW))),
+			lipgloss.NewStyle().Foreground(kindColor).Render(fmt.Sprintf("%-*s", kindW, e.Kind)),
+			lipgloss.NewStyle().Foreground(m.theme.Fg).Render(truncate(e.Message, msgW)),
+		))
 	}
 
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter(viewErrors, w))
+	return b.String()
+}
+
+// renderContextGauge draws a utilization bar followed by the percentage,
+// token counts and compactions. The bar turns orange past the warning
+// threshold.
+func (t Theme) renderContextGauge(s api.Session, width int) string {
+	dim := lipgloss.NewStyle().Foreground(t.Dim)
+	compactions := ""
+	if s.CompactionCount > 0 {
+		compactions = dim.Render(fmt.Sprintf("  · %d compactions, last %s", s.CompactionCount, timeAgo(s.LastCompactionAt)))
+	}
+	if s.ContextLimit == 0 {
+		return lipgloss.NewStyle().Foreground(t.Fg).Render(formatTokens(s.ContextTokens)) +
+			dim.Render(" tokens (limit unknown)") + compactions
+	}
+
+	ratio := math.Min(s.ContextUtilization, 1)
+	filled := int(math.Round(ratio * float64(width)))
+	color := t.Green
+	if s.ContextWarning {
+		color = t.Orange
+	}
+	if s.ContextUtilization >= 0.95 {
+		color = t.Red
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(t.Dimmer).Render(strings.Repeat("░", width-filled))
+	return bar + " " +
+		lipgloss.NewStyle().Foreground(color).Bold(true).Render(fmt.Sprintf("%3.0f%%", s.ContextUtilization*100)) +
+		dim.Render(fmt.Sprintf("  %s / %s", formatTokens(s.ContextTokens), formatTokens(s.ContextLimit))) +
+		compactions
+}
+
+// renderContextHistory draws utilization over time as a sparkline on a
+// fixed 0-100% scale.
+func (t Theme) renderContextHistory(points []api.ContextPoint, width int) string {
 	blocks := []rune("▁▂▃▄▅▆▇█")
+	count := minInt(len(points), width)
+	var sb strings.Builder
+	for i := len(points) - count; i < len(points); i++ {
+		u := math.Min(points[i].Utilization, 1)
+		idx := clampInt(int(math.Round(u*float64(len(blocks)-1))), 0, len(blocks)-1)
+		sb.WriteString(lipgloss.NewStyle().Foreground(t.Cyan).Render(string(blocks[idx])))
+	}
+	return sb.String()
+}
+
+// ── Latency View ──
+func (m model) renderLatency(w int) string {
+	var b strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Dim).Bold(true)
+	if len(m.latency.Models) == 0 {
+		empty := "  No timed turns yet"
+		if !m.viewLoaded {
+			empty = "  Loading…"
+		}
+		b.WriteString(headerStyle.Render("  ▌ LATENCY"))
+		b.WriteString("\n\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Dim).Render(empty))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(headerStyle.Render("  ▌ TURN LATENCY BY MODEL"))
+		b.WriteString("\n")
+		b.WriteString(m.theme.renderLatencyBars(m.latency.Models, w, func(l api.LatencyStats) api.Percentiles { return l.TurnLatency }))
+		b.WriteString("\n")
+		b.WriteString(headerStyle.Render("  ▌ TOOL TIME BY MODEL"))
+		b.WriteString("\n")
+		b.WriteString(m.theme.renderLatencyBars(m.latency.Models, w, func(l api.LatencyStats) api.Percentiles { return l.ToolTime }))
+		b.WriteString("\n")
+		b.WriteString(headerStyle.Render("  ▌ THROUGHPUT BY MODEL"))
+		b.WriteString("\n")
+		for _, l := range m.latency.Models {
+			b.WriteString("  " + lipgloss.NewStyle().Foreground(m.theme.Bright).Render(fmt.Sprintf("%-24s", truncate(modelDisplay(l.Key), 24))) + " " +
+				lipgloss.NewStyle().Foreground(m.theme.Fg).Render(formatPercentiles(l.TokensPerSec, formatTokensPerSec)) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	legend := lipgloss.NewStyle().Foreground(m.theme.Green).Render("█") + lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" p50  ") +
+		lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render("▒") + lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" p95  ") +
+		lipgloss.NewStyle().Foreground(m.theme.Purple).Render("│") + lipgloss.NewStyle().Foreground(m.theme.Dim).Render(" p99")
+	b.WriteString("  " + legend + "\n\n")
+	b.WriteString(m.renderFooter(viewLatency, w))
+	return b.String()
+}
+
+// ── Heatmap View ──
+
+// renderHeatmap draws messages (or cost) per weekday and hour as shaded
+// blocks, Monday first, scaled to the busiest cell.
+func (m model) renderHeatmap() string {
+	var b strings.Builder
+	headerStyle := lipgloss.NewStyle().Foreground(m.theme.Dim).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.Dim)
+
+	metric, color := "MESSAGES", m.theme.Green
+	if m.heatmapCost {
+		metric, color = "COST", m.theme.Purple
+	}
+	days := int(heatmapLookbacks[m.heatmapLookback].Hours() / 24)
+	b.WriteString(headerStyle.Render(fmt.Sprintf("  ▌ %s BY WEEKDAY × HOUR", metric)) +
+		dimStyle.Render(fmt.Sprintf("  last %d days, local time", days)))
+	b.WriteString("\n\n")
+
+	hm := m.heatmap
+	if hm == nil && !m.viewLoaded {
+		b.WriteString(dimStyle.Render("  Loading…"))
+		b.WriteString("\n")
+	} else if hm == nil {
+		b.WriteString(dimStyle.Render("  No activity data"))
+		b.WriteString("\n")
+	} else {
+		// Hour labels every three hours, two columns per hour.
+		b.WriteString("       ")
+		for h := 0; h < 24; h += 3 {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("%-6s", fmt.Sprintf("%02d", h))))
+		}
+		b.WriteString("\n")
 
-	maxVal := 0
-	for _, h := range m.hourly {
-		if h.Messages > maxVal {
-			maxVal = h.Messages
+		shades := []string{"░░", "▒▒", "▓▓", "██"}
+		for i := 0; i < 7; i++ {
+			day := time.Weekday((i + 1) % 7)
+			b.WriteString(dimStyle.Render(fmt.Sprintf("  %s  ", day.String()[:3])))
+			total := 0.0
+			for h := 0; h < 24; h++ {
+				var v, max float64
+				if m.heatmapCost {
+					v, max = hm.Cost[day][h], hm.MaxCost
+				} else {
+					v, max = float64(hm.Messages[day][h]), float64(hm.MaxMessages)
+				}
+				total += v
+				if v <= 0 || max <= 0 {
+					b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render("··"))
+					continue
+				}
+				idx := clampInt(int(math.Ceil(v/max*float64(len(shades))))-1, 0, len(shades)-1)
+				b.WriteString(lipgloss.NewStyle().Foreground(color).Render(shades[idx]))
+			}
+			if m.heatmapCost {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("  $%.2f", total)))
+			} else {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("  %d", int(total))))
+			}
+			b.WriteString("\n")
 		}
 	}
+
+	b.WriteString("\n")
+	legend := lipgloss.NewStyle().Foreground(m.theme.Dimmer).Render("··") + dimStyle.Render(" none  ")
+	for _, s := range []string{"░░", "▒▒", "▓▓", "██"} {
+		legend += lipgloss.NewStyle().Foreground(color).Render(s) + " "
+	}
+	b.WriteString("  " + legend + dimStyle.Render("busiest") + "\n\n")
+	w, _ := m.size()
+	b.WriteString(m.renderFooter(viewHeatmap, w))
+	return b.String()
+}
+
+// renderLatencyBars draws one horizontal bar per model: solid up to p50,
+// shaded up to p95 and a marker at p99, all on a shared scale.
+func (t Theme) renderLatencyBars(stats []api.LatencyStats, w int, pick func(api.LatencyStats) api.Percentiles) string {
+	nameW := 24
+	numW := 42
+	barW := w - nameW - numW - 6
+	if barW < 10 {
+		barW = 10
+	}
+
+	maxVal := 0.0
+	for _, l := range stats {
+		maxVal = math.Max(maxVal, pick(l).P99)
+	}
 	if maxVal == 0 {
-		return lipgloss.NewStyle().Foreground(colorDim).Render(strings.Repeat("▁", minInt(len(m.hourly), width)))
+		maxVal = 1
 	}
 
 	var sb strings.Builder
-	count := minInt(len(m.hourly), width)
-	for i := len(m.hourly) - count; i < len(m.hourly); i++ {
-		ratio := float64(m.hourly[i].Messages) / float64(maxVal)
-		idx := int(math.Round(ratio * float64(len(blocks)-1)))
+	for _, l := range stats {
+		p := pick(l)
+		name := lipgloss.NewStyle().Foreground(t.Bright).Render(fmt.Sprintf("%-*s", nameW, truncate(modelDisplay(l.Key), nameW)))
+		if p.Count == 0 {
+			sb.WriteString("  " + name + " " + lipgloss.NewStyle().Foreground(t.Dimmer).Render("no samples") + "\n")
+			continue
+		}
+		col := func(v float64) int {
+			return clampInt(int(math.Round(v/maxVal*float64(barW))), 0, barW-1)
+		}
+		p50, p95, p99 := col(p.P50), col(p.P95), col(p.P99)
+		var bar strings.Builder
+		for i := 0; i < barW; i++ {
+			switch {
+			case i == p99 && p99 > p95:
+				bar.WriteString(lipgloss.NewStyle().Foreground(t.Purple).Render("│"))
+			case i <= p50:
+				bar.WriteString(lipgloss.NewStyle().Foreground(t.Green).Render("█"))
+			case i <= p95:
+				bar.WriteString(lipgloss.NewStyle().Foreground(t.Dimmer).Render("▒"))
+			default:
+				bar.WriteString(" ")
+			}
+		}
+		sb.WriteString("  " + name + " " + bar.String() + " " +
+			lipgloss.NewStyle().Foreground(t.Fg).Render(formatPercentiles(p, formatMillis)) + "\n")
+	}
+	return sb.String()
+}
+
+// lastDayActivity returns 24 hourly buckets ending with the current hour.
+func lastDayActivity(c *api.Client) ([]api.ActivityBucket, error) {
+	now := c.Now()
+	return c.GetActivity(now.Add(-23*time.Hour), now, api.BucketHour, api.ActivityFilter{})
+}
+
+// loadTimeline clears the timeline and fetches the selected session's in
+// the background.
+func (m *model) loadTimeline() tea.Cmd {
+	m.timeline = nil
+	s, ok := m.selectedSession()
+	if !ok {
+		return nil
+	}
+	client, id := m.client, s.SessionID
+	return func() tea.Msg {
+		tl, _ := client.GetSessionTimeline(id, 0)
+		return timelineLoadedMsg{id, tl}
+	}
+}
+
+// renderTimeline draws the selected session's message and cost buckets and
+// its tool calls on a shared time axis, headed by start, end and duration.
+func (m model) renderTimeline(width int) []string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Dim)
+	dimStyle := lipgloss.NewStyle().Foreground(m.theme.Dim)
+	tl := m.timeline
+	if tl == nil || len(tl.Buckets) == 0 {
+		return []string{headerStyle.Render("TIMELINE"), dimStyle.Render("no messages")}
+	}
+
+	start, end := m.client.In(time.UnixMilli(tl.Start)), m.client.In(time.UnixMilli(tl.End))
+	layout := "15:04"
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() {
+		layout = "Jan 2 15:04"
+	}
+	header := headerStyle.Render("TIMELINE") + "  " +
+		lipgloss.NewStyle().Foreground(m.theme.Fg).Render(start.Format(layout)+" → "+end.Format(layout)) +
+		dimStyle.Render(fmt.Sprintf("  · %s  · %s buckets", formatDuration(end.Sub(start)), tl.Bucket))
+
+	// Buckets are merged when there are more than columns.
+	cols := minInt(len(tl.Buckets), width)
+	colOf := func(i int) int { return i * cols / len(tl.Buckets) }
+	messages := make([]float64, cols)
+	costs := make([]float64, cols)
+	for i, b := range tl.Buckets {
+		messages[colOf(i)] += float64(b.Messages)
+		costs[colOf(i)] += b.Cost
+	}
+	tools := make([]int, cols) // 0 none, 1 call, 2 failed call
+	for _, t := range tl.ToolCalls {
+		at := time.UnixMilli(t.At)
+		idx := sort.Search(len(tl.Buckets), func(i int) bool { return tl.Buckets[i].Start.After(at) }) - 1
 		if idx < 0 {
-			idx = 0
+			continue
 		}
-		if m.hourly[i].Messages > 0 {
-			sb.WriteString(lipgloss.NewStyle().Foreground(colorGreen).Render(string(blocks[idx])))
-		} else {
-			sb.WriteString(lipgloss.NewStyle().Foreground(colorDimmer).Render(string(blocks[0])))
+		mark := 1
+		if t.Failed {
+			mark = 2
+		}
+		tools[colOf(idx)] = maxInt(tools[colOf(idx)], mark)
+	}
+
+	var toolRow strings.Builder
+	for _, t := range tools {
+		switch t {
+		case 2:
+			toolRow.WriteString(lipgloss.NewStyle().Foreground(m.theme.Red).Render("┃"))
+		case 1:
+			toolRow.WriteString(lipgloss.NewStyle().Foreground(m.theme.Cyan).Render("│"))
+		default:
+			toolRow.WriteString(" ")
+		}
+	}
+
+	label := lipgloss.NewStyle().Foreground(m.theme.Dim).Width(10)
+	return []string{
+		header,
+		label.Render("msgs") + m.theme.sparkline(messages, m.theme.Green),
+		label.Render("cost") + m.theme.sparkline(costs, m.theme.Purple),
+		label.Render(fmt.Sprintf("tools %d", len(tl.ToolCalls))) + toolRow.String(),
+	}
+}
+
+// sparkline draws values scaled to their maximum, with empty columns dimmed.
+func (t Theme) sparkline(values []float64, color lipgloss.TerminalColor) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	maxVal := 0.0
+	for _, v := range values {
+		maxVal = math.Max(maxVal, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		if v <= 0 || maxVal == 0 {
+			sb.WriteString(lipgloss.NewStyle().Foreground(t.Dimmer).Render(string(blocks[0])))
+			continue
 		}
+		idx := int(math.Round(v / maxVal * float64(len(blocks)-1)))
+		sb.WriteString(lipgloss.NewStyle().Foreground(color).Render(string(blocks[idx])))
 	}
 	return sb.String()
 }
 
 // ── Helpers ──
 
+// badges returns the session's status markers (stuck, context, anomalies)
+// separated by two spaces, or "" when there are none.
+func (t Theme) badges(s api.Session) string {
+	var parts []string
+	for _, b := range []string{t.pinBadge(s), t.stuckBadge(s), t.contextBadge(s), t.anomalyBadge(s), t.tagBadges(s)} {
+		if b != "" {
+			parts = append(parts, b)
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// pinBadge marks pinned sessions.
+func (t Theme) pinBadge(s api.Session) string {
+	if !s.Pinned {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(t.Orange).Render("★")
+}
+
+// tagBadges renders the session's tags as "#tag".
+func (t Theme) tagBadges(s api.Session) string {
+	if len(s.Tags) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(t.Purple).Render("#" + strings.Join(s.Tags, " #"))
+}
+
+// contextBadge returns a "◔ 85%" marker for sessions over the context
+// warning threshold, or "" otherwise.
+func (t Theme) contextBadge(s api.Session) string {
+	if !s.ContextWarning {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(t.Orange).Bold(true).Render(
+		fmt.Sprintf("◔ %.0f%%", s.ContextUtilization*100))
+}
+
+// stuckBadge returns an orange "◌ stuck 12m" marker for stuck sub-agents,
+// or "" otherwise.
+func (t Theme) stuckBadge(s api.Session) string {
+	if !s.Stuck {
+		return ""
+	}
+	since := strings.TrimSuffix(timeAgo(s.LastProgressAt), " ago")
+	return lipgloss.NewStyle().Foreground(t.Orange).Bold(true).Render("◌ stuck " + since)
+}
+
+// anomalyBadge returns a red "⚠ kind" marker listing the session's
+// anomaly kinds, or "" when there are none.
+func (t Theme) anomalyBadge(s api.Session) string {
+	if len(s.Anomalies) == 0 {
+		return ""
+	}
+	kinds := make([]string, len(s.Anomalies))
+	for i, a := range s.Anomalies {
+		kinds[i] = a.Kind
+	}
+	return lipgloss.NewStyle().Foreground(t.Red).Bold(true).Render("⚠ " + strings.Join(kinds, ","))
+}
+
 func timeAgo(ms int64) string {
 	d := time.Since(time.UnixMilli(ms))
 	switch {
@@ -940,6 +2686,57 @@ func timeAgo(ms int64) string {
 	}
 }
 
+// formatMillis renders a duration given in milliseconds, e.g. "850ms",
+// "4.2s" or "2m05s".
+func formatMillis(ms float64) string {
+	switch {
+	case ms < 1000:
+		return fmt.Sprintf("%.0fms", ms)
+	case ms < 60000:
+		return fmt.Sprintf("%.1fs", ms/1000)
+	default:
+		d := time.Duration(ms) * time.Millisecond
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
+// formatDuration renders a span as e.g. "45s", "46m", "3h12m" or "2d4h".
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(d.Hours()/24), int(d.Hours())%24)
+	}
+}
+
+// formatTokens renders a token count as e.g. "950", "156k" or "1.2M".
+func formatTokens(n int) string {
+	switch {
+	case n >= 1000000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1000:
+		return fmt.Sprintf("%.0fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+func formatTokensPerSec(v float64) string {
+	return fmt.Sprintf("%.0f tok/s", v)
+}
+
+func formatPercentiles(p api.Percentiles, format func(float64) string) string {
+	if p.Count == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("p50 %s  p95 %s  p99 %s  (n=%d)", format(p.P50), format(p.P95), format(p.P99), p.Count)
+}
+
 func modelDisplay(m string) string {
 	if m == "" {
 		return "unknown"
@@ -995,7 +2792,68 @@ func clampInt(v, lo, hi int) int {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	configPath := flag.String("config", config.DefaultPath(), "config file")
+	dir := flag.String("dir", "", "OpenClaw data directory")
+	interval := flag.Duration("interval", 0, "refresh interval")
+	budget := flag.Float64("budget", 0, "monthly budget in dollars")
+	dailyBudget := flag.Float64("daily-budget", 0, "daily budget in dollars")
+	timezone := flag.String("timezone", "", "IANA time zone for days and hours")
+	theme := flag.String("theme", "", "color theme: auto, gmork, light, high-contrast, monochrome or a theme file")
+	noMouse := flag.Bool("no-mouse", false, "leave the mouse to the terminal, e.g. for selecting text")
+	once := flag.Bool("once", false, "print one snapshot to stdout and exit")
+	width := flag.Int("width", 0, "snapshot width for -once (default $COLUMNS, or 120)")
+	format := flag.String("format", "dashboard", "snapshot format for -once: dashboard, status, waybar, i3blocks or a template")
+	filter := flag.String("filter", "", "filter query for -once")
+	flag.Parse()
+
+	// Flags given on the command line override the file and environment.
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	loader := &config.Loader{Path: *configPath, Override: func(c *config.Config) {
+		if set["dir"] {
+			c.OpenclawDir = *dir
+		}
+		if set["interval"] {
+			c.Interval = config.Duration(*interval)
+		}
+		if set["budget"] {
+			c.Budget.Monthly = *budget
+		}
+		if set["daily-budget"] {
+			c.Budget.Daily = *dailyBudget
+		}
+		if set["timezone"] {
+			c.Timezone = *timezone
+		}
+		if set["theme"] {
+			c.Theme = *theme
+		}
+	}}
+
+	if *once {
+		w := *width
+		if w <= 0 {
+			w, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+		}
+		if w <= 0 {
+			w = 120
+		}
+		if err := runOnce(os.Stdout, loader, w, *format, *filter); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	out := &lockedFile{File: os.Stdout}
+	opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithOutput(out)}
+	if !*noMouse {
+		opts = append(opts, tea.WithMouseAllMotion())
+	}
+	m := initialModel(loader)
+	m.mouse = !*noMouse
+	m.out = out
+	p := tea.NewProgram(m, opts...)
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
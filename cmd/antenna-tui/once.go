@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Caryyon/antenna/internal/api"
+	"github.com/Caryyon/antenna/internal/config"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusFormat is the -format status template.
+const statusFormat = `📡 {{.Active}} active {{cost .Today}} today`
+
+// snapshotData is what -format templates see.
+type snapshotData struct {
+	Sessions  int // visible sessions
+	Active    int
+	Idle      int
+	Subagents int
+	Crons     int
+	Stuck     int
+	Errors    int
+	Anomalies int
+
+	Today       float64 // cost today
+	Total       float64 // cost of all time
+	EndOfDay    float64 // projected cost today; 0 with -filter
+	EndOfMonth  float64 // projected cost this month; 0 with -filter
+	OverBudget  bool    // today or the month is projected past its budget
+	LastRefresh string  // HH:MM:SS
+}
+
+var snapshotFuncs = template.FuncMap{
+	"cost": func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+}
+
+// runOnce loads the dashboard once and writes it to out in a format
+// instead of starting the interactive program:
+//
+//	dashboard  the dashboard as the TUI draws it, width columns wide and
+//	           as tall as it needs to be, without the footer
+//	status     one line for a status bar; see statusFormat
+//	waybar     a Waybar custom module JSON object
+//	i3blocks   an i3blocks JSON block
+//	otherwise  a text/template over snapshotData, with cost formatting a
+//	           dollar amount
+//
+// The saved TUI filter does not apply; filter does.
+func runOnce(out io.Writer, loader *config.Loader, width int, format, filter string) error {
+	m := initialModel(loader)
+	if m.status != "" {
+		fmt.Fprintln(os.Stderr, m.status)
+	}
+	q, err := api.ParseSessionQuery(filter)
+	if err != nil {
+		return fmt.Errorf("filter: %w", err)
+	}
+	m.filter = q
+	m.snapshot = true
+	m.applyLoad(m.load()().(dashboardLoadedMsg))
+	if m.err != nil && len(m.dashboard.Sessions) == 0 {
+		return m.err
+	}
+	m.regroup()
+
+	switch format {
+	case "", "dashboard":
+		m.width, m.height = width, m.fitHeight(width)
+		m.followCursors()
+		_, err := fmt.Fprintln(out, strings.TrimRight(m.View(), "\n"))
+		return err
+	case "status":
+		format = statusFormat
+	case "waybar", "i3blocks":
+		status, err := m.renderSnapshot(statusFormat)
+		if err != nil {
+			return err
+		}
+		return json.NewEncoder(out).Encode(m.snapshotJSON(format, status))
+	}
+	line, err := m.renderSnapshot(format)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, line)
+	return err
+}
+
+// fitHeight returns a terminal height at which the dashboard shows every
+// visible session at width w.
+func (m model) fitHeight(w int) int {
+	need := func(a, b int) int { return maxInt(a, 1) + maxInt(b, 1) + 3 }
+	rows := maxInt(need(len(m.groups[sectionActive]), len(m.groups[sectionIdle])),
+		need(len(m.groups[sectionSubs]), len(m.groups[sectionCrons])))
+	if _, _, side := dashboardColumns(w); !side {
+		rows *= 2 // each panel gets half the rows when stacked
+	}
+	h := 40
+	for panelRows(h) < rows {
+		h++
+	}
+	return h
+}
+
+func (m model) snapshotData() snapshotData {
+	active, idle, subs, crons := m.grouped()
+	d := snapshotData{
+		Active:    len(active),
+		Idle:      len(idle),
+		Subagents: len(subs),
+		Crons:     len(crons),
+	}
+	if !m.lastRefresh.IsZero() {
+		d.LastRefresh = m.client.In(m.lastRefresh).Format("15:04:05")
+	}
+	for _, s := range m.dashboard.Sessions {
+		if !m.visible(s) {
+			continue
+		}
+		d.Sessions++
+		d.Today += s.TodayCost
+		d.Total += s.TotalCost
+		d.Errors += s.ErrorCount
+		d.Anomalies += len(s.Anomalies)
+		if s.Stuck {
+			d.Stuck++
+		}
+	}
+	if m.filter.IsZero() {
+		d.EndOfDay = m.forecast.EndOfDay.Expected
+		d.EndOfMonth = m.forecast.EndOfMonth.Expected
+		d.OverBudget = m.forecast.OverDailyBudget || m.forecast.OverBudget
+	}
+	return d
+}
+
+func (m model) renderSnapshot(format string) (string, error) {
+	t, err := template.New("format").Funcs(snapshotFuncs).Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("format: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, m.snapshotData()); err != nil {
+		return "", fmt.Errorf("format: %w", err)
+	}
+	return b.String(), nil
+}
+
+// snapshotJSON builds a Waybar or i3blocks block around the status line.
+// Both get a state to style on: over-budget, stuck, active or idle.
+func (m model) snapshotJSON(format, status string) any {
+	d := m.snapshotData()
+	state, color := "idle", m.theme.Idle
+	switch {
+	case d.OverBudget:
+		state, color = "over-budget", m.theme.Red
+	case d.Stuck > 0:
+		state, color = "stuck", m.theme.Orange
+	case d.Active > 0:
+		state, color = "active", m.theme.Green
+	}
+
+	if format == "waybar" {
+		tooltip := fmt.Sprintf("%d sessions: %d active, %d idle, %d sub-agents, %d cron\nToday $%.2f, total $%.2f",
+			d.Sessions, d.Active, d.Idle, d.Subagents, d.Crons, d.Today, d.Total)
+		if m.filter.IsZero() {
+			tooltip += fmt.Sprintf("\nProjected: $%.2f today, $%.2f this month", d.EndOfDay, d.EndOfMonth)
+		}
+		if d.Errors > 0 || d.Stuck > 0 {
+			tooltip += fmt.Sprintf("\n%d errors, %d stuck", d.Errors, d.Stuck)
+		}
+		return struct {
+			Text    string   `json:"text"`
+			Alt     string   `json:"alt"`
+			Tooltip string   `json:"tooltip"`
+			Class   []string `json:"class"`
+		}{status, state, tooltip, []string{state}}
+	}
+
+	block := struct {
+		FullText  string `json:"full_text"`
+		ShortText string `json:"short_text"`
+		Color     string `json:"color,omitempty"`
+		Urgent    bool   `json:"urgent,omitempty"`
+	}{
+		FullText:  status,
+		ShortText: fmt.Sprintf("📡 $%.2f", d.Today),
+		Urgent:    d.OverBudget,
+	}
+	if c, ok := color.(lipgloss.Color); ok && strings.HasPrefix(string(c), "#") {
+		block.Color = string(c) // i3blocks takes hex colors only
+	}
+	return block
+}
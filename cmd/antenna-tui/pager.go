@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// externalDoneMsg reports that a pager or editor started by openExternal
+// has exited.
+type externalDoneMsg struct {
+	err  error
+	name string // program, for error messages
+	tmp  string // rendered transcript to remove, if any
+}
+
+// openExternal suspends the TUI and shows the selected session's
+// transcript in another program:
+//
+//	pager     rendered as plain text, in $PAGER (less)
+//	pagerRaw  the .jsonl file itself, in $PAGER
+//	editor    rendered as Markdown, in $VISUAL or $EDITOR (vi)
+//
+// A rendered transcript goes to a temporary file, removed afterwards.
+func (m *model) openExternal(action string) tea.Cmd {
+	s, ok := m.selectedSession()
+	if !ok {
+		m.status = "no session selected"
+		return nil
+	}
+
+	program := firstEnv("less", "PAGER")
+	if action == "editor" {
+		program = firstEnv("vi", "VISUAL", "EDITOR")
+	}
+	args := strings.Fields(program)
+
+	path, tmp := m.client.TranscriptPath(s.SessionID), ""
+	if action != "pagerRaw" {
+		format, ext := "text", ".txt"
+		if action == "editor" {
+			format, ext = "markdown", ".md"
+		}
+		text, err := m.client.RenderTranscript(s, format)
+		if err != nil {
+			m.status = "transcript: " + err.Error()
+			return nil
+		}
+		f, err := os.CreateTemp("", "antenna-*"+ext)
+		if err != nil {
+			m.status = "transcript: " + err.Error()
+			return nil
+		}
+		_, err = f.WriteString(text)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(f.Name())
+			m.status = "transcript: " + err.Error()
+			return nil
+		}
+		path, tmp = f.Name(), f.Name()
+	}
+
+	c := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return externalDoneMsg{err: err, name: args[0], tmp: tmp}
+	})
+}
+
+// externalDone cleans up after openExternal and brings the TUI back as it
+// was: mouse reporting, which the terminal handover turns off, and data
+// that may have gone stale meanwhile.
+func (m model) externalDone(msg externalDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.tmp != "" {
+		os.Remove(msg.tmp)
+	}
+	if msg.err != nil {
+		m.status = fmt.Sprintf("%s: %v", msg.name, msg.err)
+	}
+	var cmds []tea.Cmd
+	if m.mouse {
+		cmds = append(cmds, tea.EnableMouseAllMotion)
+	}
+	if !m.refreshing() {
+		cmds = append(cmds, m.refresh())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// firstEnv returns the first of the environment variables that is set, or
+// def.
+func firstEnv(def string, names ...string) string {
+	for _, name := range names {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return def
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Caryyon/antenna/internal/api"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// palette is the command palette: a fuzzy-searchable list of every
+// command, opened with : or ctrl+p.
+type palette struct {
+	input   []rune
+	cursor  int            // selected match
+	matches []paletteEntry // entries matching input, best first
+}
+
+// paletteEntry is one line of the palette. Running it runs its command
+// with arg.
+type paletteEntry struct {
+	line string // what is shown, matched and remembered, e.g. "theme light"
+	help string
+	cmd  string // name of a paletteCommand, or a keymap action
+	arg  string
+}
+
+// paletteCommand is a palette command that takes an argument, typed after
+// its name: "filter kind:cron", "export out.txt". runCommand runs it.
+type paletteCommand struct {
+	name string
+	args string // argument hint; optional in brackets
+	help string
+}
+
+var paletteCommands = []paletteCommand{
+	{"session", "NAME", "Open a session by name or ID"},
+	{"view", "NAME", "Switch to dashboard, detail, errors, latency or heatmap"},
+	{"filter", "QUERY", "Set the filter"},
+	{"export", "[FILE]", "Save the current view as plain text"},
+	{"copy", "id|path|summary|markdown", "Copy the selected session's ID, transcript path, summary or card"},
+	{"theme", "NAME", "Switch the color theme until restart"},
+}
+
+// maxRecent bounds the remembered palette commands.
+const maxRecent = 10
+
+// paletteRows is how many matches the palette shows.
+const paletteRows = 8
+
+func findPaletteCommand(name string) (paletteCommand, bool) {
+	for _, c := range paletteCommands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return paletteCommand{}, false
+}
+
+// paletteEntries lists every entry the palette offers in the current view:
+// its keymap actions, each visible session, each view and each theme.
+func (m model) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	for _, b := range bindings {
+		if b.action == "palette" || !b.in(m.view) {
+			continue
+		}
+		entries = append(entries, paletteEntry{line: b.action, help: b.help, cmd: b.action})
+	}
+	for _, c := range paletteCommands {
+		if _, isAction := m.keys.keys[c.name]; isAction {
+			continue // "filter" opens the prompt; "filter QUERY" still works
+		}
+		entries = append(entries, paletteEntry{line: c.name, help: c.help, cmd: c.name})
+	}
+	for _, v := range []string{"dashboard", "detail", "errors", "latency", "heatmap"} {
+		entries = append(entries, paletteEntry{line: "view " + v, help: "Switch view", cmd: "view", arg: v})
+	}
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, paletteEntry{line: "theme " + name, help: "Color theme", cmd: "theme", arg: name})
+	}
+	for _, sec := range m.groups {
+		for _, s := range sec {
+			entries = append(entries, paletteEntry{line: "session " + s.Name, help: modelDisplay(s.Model), cmd: "session", arg: s.SessionID})
+		}
+	}
+	return entries
+}
+
+// filterPalette ranks entries against the input. An empty input lists
+// recent commands first.
+func (m *model) filterPalette() {
+	p := m.palette
+	query := strings.TrimSpace(string(p.input))
+	entries := m.paletteEntries()
+	recent := make(map[string]int, len(m.recent))
+	for i, line := range m.recent {
+		recent[line] = len(m.recent) - i
+	}
+
+	type scored struct {
+		e     paletteEntry
+		score int
+	}
+	var out []scored
+	if query == "" {
+		for _, line := range m.recent {
+			if e, ok := m.resolvePalette(line); ok {
+				out = append(out, scored{e, 0})
+			}
+		}
+		for _, e := range entries {
+			if _, seen := recent[e.line]; !seen {
+				out = append(out, scored{e, 0})
+			}
+		}
+	} else {
+		for _, e := range entries {
+			score, ok := fuzzyScore(query, e.line)
+			if helpScore, helpOK := fuzzyScore(query, e.help); helpOK && (!ok || helpScore/2 > score) {
+				score, ok = helpScore/2, true
+			}
+			if ok {
+				out = append(out, scored{e, score + 5*recent[e.line]})
+			}
+		}
+		sort.SliceStable(out, func(i, j int) bool { return out[i].score > out[j].score })
+	}
+	p.matches = p.matches[:0]
+	for _, s := range out {
+		p.matches = append(p.matches, s.e)
+	}
+	p.cursor = clampInt(p.cursor, 0, maxInt(len(p.matches)-1, 0))
+}
+
+// fuzzyScore reports whether the runes of pattern appear in order in text,
+// ignoring case, and scores the match: consecutive runes and runes at the
+// start of words count more, and a gap costs a little.
+func fuzzyScore(pattern, text string) (int, bool) {
+	pat := []rune(strings.ToLower(pattern))
+	txt := []rune(text)
+	score, pi, last := 0, 0, -2
+	for ti := 0; ti < len(txt) && pi < len(pat); ti++ {
+		if unicode.ToLower(txt[ti]) != pat[pi] {
+			continue
+		}
+		switch {
+		case ti == last+1:
+			score += 5
+		case ti == 0 || !unicode.IsLetter(txt[ti-1]) || unicode.IsUpper(txt[ti]):
+			score += 4
+		default:
+			score++
+		}
+		if last >= 0 && ti > last+1 {
+			score--
+		}
+		last = ti
+		pi++
+	}
+	if pi < len(pat) {
+		return 0, false
+	}
+	return score, true
+}
+
+// resolvePalette turns a command line into an entry: an exact entry line,
+// or a command name followed by its argument.
+func (m model) resolvePalette(line string) (paletteEntry, bool) {
+	line = strings.TrimSpace(line)
+	for _, e := range m.paletteEntries() {
+		if e.line == line {
+			return e, true
+		}
+	}
+	name, arg, _ := strings.Cut(line, " ")
+	if _, ok := findPaletteCommand(name); ok {
+		return paletteEntry{line: line, cmd: name, arg: strings.TrimSpace(arg)}, true
+	}
+	return paletteEntry{}, false
+}
+
+// updatePalette handles a key while the palette is open. Enter runs the
+// typed command line if it names a command, otherwise the selected match;
+// tab copies the selected match into the input to add an argument.
+func (m model) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.palette
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.palette = nil
+		return m, nil
+	case "up", "ctrl+k", "ctrl+p":
+		p.cursor = maxInt(p.cursor-1, 0)
+		return m, nil
+	case "down", "ctrl+j", "ctrl+n":
+		p.cursor = minInt(p.cursor+1, maxInt(len(p.matches)-1, 0))
+		return m, nil
+	case "tab":
+		if p.cursor < len(p.matches) {
+			p.input = []rune(p.matches[p.cursor].line + " ")
+			m.filterPalette()
+		}
+		return m, nil
+	case "enter":
+		e, ok := m.pickPalette()
+		if !ok {
+			m.status = fmt.Sprintf("no command %q", strings.TrimSpace(string(p.input)))
+			m.palette = nil
+			return m, nil
+		}
+		if c, isCmd := findPaletteCommand(e.cmd); isCmd && e.arg == "" && !m.isAction(e.cmd) && !strings.HasPrefix(c.args, "[") {
+			p.input = []rune(e.cmd + " ") // needs an argument
+			m.filterPalette()
+			return m, nil
+		}
+		m.palette = nil
+		return m.runPalette(e)
+	case "backspace":
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+	case "ctrl+u":
+		p.input = nil
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			p.input = append(p.input, msg.Runes...)
+		}
+	}
+	p.cursor = 0
+	m.filterPalette()
+	return m, nil
+}
+
+// pickPalette chooses the entry enter runs: the entry the input spells
+// exactly; else, if the input starts with a command name, the selected
+// match when it completes the input, or the command with the rest of the
+// input as its argument; else the selected match.
+func (m model) pickPalette() (paletteEntry, bool) {
+	p := m.palette
+	input := strings.TrimSpace(string(p.input))
+	var selected *paletteEntry
+	if p.cursor < len(p.matches) {
+		selected = &p.matches[p.cursor]
+	}
+	for _, e := range m.paletteEntries() {
+		if e.line == input {
+			return e, true
+		}
+	}
+	name, arg, _ := strings.Cut(input, " ")
+	if _, ok := findPaletteCommand(name); ok {
+		if selected != nil && strings.HasPrefix(selected.line, input) {
+			return *selected, true
+		}
+		return paletteEntry{line: input, cmd: name, arg: strings.TrimSpace(arg)}, true
+	}
+	if selected != nil {
+		return *selected, true
+	}
+	return paletteEntry{}, false
+}
+
+// runPalette runs an entry and remembers it as a recent command.
+func (m model) runPalette(e paletteEntry) (tea.Model, tea.Cmd) {
+	m.recent = append([]string{e.line}, removeString(m.recent, e.line)...)
+	if len(m.recent) > maxRecent {
+		m.recent = m.recent[:maxRecent]
+	}
+	m.saveState()
+	if _, ok := findPaletteCommand(e.cmd); ok && (e.arg != "" || !m.isAction(e.cmd)) {
+		cmd := m.runCommand(e.cmd, e.arg)
+		m.followCursors()
+		return m, cmd
+	}
+	return m.runAction(e.cmd)
+}
+
+func (m model) isAction(name string) bool {
+	_, ok := m.keys.keys[name]
+	return ok
+}
+
+func removeString(list []string, s string) []string {
+	var out []string
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// renderPalette draws the palette: the input line over the best matches.
+func (m model) renderPalette(w int) []string {
+	p := m.palette
+	lines := []string{
+		lipgloss.NewStyle().Foreground(m.theme.Green).Bold(true).Render("  : ") +
+			lipgloss.NewStyle().Foreground(m.theme.Bright).Render(string(p.input)) +
+			lipgloss.NewStyle().Foreground(m.theme.Green).Render("█") +
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render("  enter run  tab complete  esc close"),
+	}
+	from := clampInt(p.cursor-paletteRows+1, 0, maxInt(len(p.matches)-paletteRows, 0))
+	for i := from; i < len(p.matches) && i < from+paletteRows; i++ {
+		e := p.matches[i]
+		hint := e.help
+		if c, ok := findPaletteCommand(e.cmd); ok && e.arg == "" && !m.isAction(e.cmd) {
+			hint = c.args + "  " + hint
+		}
+		line := "    " + lipgloss.NewStyle().Foreground(m.theme.Fg).Render(padRight(truncate(e.line, 40), 40)) +
+			lipgloss.NewStyle().Foreground(m.theme.Dim).Render(truncate(hint, maxInt(w-46, 10)))
+		if i == p.cursor {
+			line = m.theme.selected().Render(padRight(line, w))
+		}
+		lines = append(lines, line)
+	}
+	if len(p.matches) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(m.theme.Dim).Render("    no matching commands"))
+	}
+	return lines
+}
+
+// ── Palette Commands ──
+
+// runCommand runs the palette command name with arg.
+func (m *model) runCommand(name, arg string) tea.Cmd {
+	if m.view == viewTail && (name == "session" || name == "view") {
+		m.tailGen++ // stop following before switching views
+	}
+	switch name {
+	case "session":
+		return m.openSessionNamed(arg)
+	case "view":
+		return m.switchView(arg)
+	case "filter":
+		m.setFilter(arg)
+	case "export":
+		return m.exportView(arg)
+	case "copy":
+		return m.copySession(arg)
+	case "theme":
+		return m.switchTheme(arg)
+	}
+	return nil
+}
+
+// openSessionNamed opens the detail of the visible session with the given
+// ID or name, or else the first whose name contains it.
+func (m *model) openSessionNamed(name string) tea.Cmd {
+	var found *api.Session
+	for _, sec := range m.groups {
+		for i, s := range sec {
+			if s.SessionID == name || strings.EqualFold(s.Name, name) {
+				found = &sec[i]
+			}
+		}
+	}
+	if found == nil {
+		for _, sec := range m.groups {
+			for i, s := range sec {
+				if found == nil && strings.Contains(strings.ToLower(s.Name), strings.ToLower(name)) {
+					found = &sec[i]
+				}
+			}
+		}
+	}
+	if found == nil || name == "" {
+		m.status = fmt.Sprintf("no visible session %q", name)
+		return nil
+	}
+	m.selectSession(found.SessionID)
+	m.view = viewDetail
+	return m.loadTimeline()
+}
+
+// switchView opens a view by name.
+func (m *model) switchView(name string) tea.Cmd {
+	actions := map[string]string{"errors": "errors", "latency": "latency", "heatmap": "heatmap", "detail": "open"}
+	if name == "dashboard" {
+		m.view = viewDashboard
+		return nil
+	}
+	action, ok := actions[name]
+	if !ok {
+		m.status = fmt.Sprintf("unknown view %q", name)
+		return nil
+	}
+	m.view = viewDashboard
+	next, cmd := m.runAction(action)
+	*m = next.(model)
+	return cmd
+}
+
+// exportView writes the current view, without colors, to path or to
+// antenna-VIEW-TIME.txt in the working directory.
+func (m *model) exportView(path string) tea.Cmd {
+	names := map[view]string{viewDashboard: "dashboard", viewDetail: "detail", viewErrors: "errors",
+		viewLatency: "latency", viewHeatmap: "heatmap", viewTail: "follow"}
+	if path == "" {
+		path = fmt.Sprintf("antenna-%s-%s.txt", names[m.view], time.Now().Format("20060102-150405"))
+	}
+	snapshot := *m
+	snapshot.status, snapshot.palette = "", nil
+	text := ansi.Strip(snapshot.View())
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		m.status = "export: " + err.Error()
+		return nil
+	}
+	m.status = "exported to " + path
+	return nil
+}
+
+// switchTheme changes the theme for this run; the config file's theme
+// returns on restart or when the file changes.
+func (m *model) switchTheme(name string) tea.Cmd {
+	t, err := loadTheme(name, m.loader.Path)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	m.theme = t
+	m.status = "theme " + t.Name
+	return nil
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds every color the TUI draws with. The accent names come from
+// the Gmork palette; other themes map them to colors with the same role.
+type Theme struct {
+	Name string
+
+	Border lipgloss.TerminalColor // dividers
+	Green  lipgloss.TerminalColor // active sessions, today's cost, messages
+	Cyan   lipgloss.TerminalColor // models, tool calls, sort
+	Purple lipgloss.TerminalColor // sub-agents, cost, filter
+	Orange lipgloss.TerminalColor // cron jobs, warnings
+	Red    lipgloss.TerminalColor // errors, over budget
+	Idle   lipgloss.TerminalColor // idle sessions
+	Dim    lipgloss.TerminalColor // labels and secondary text
+	Dimmer lipgloss.TerminalColor // footers, unfocused borders, axes
+	Fg     lipgloss.TerminalColor // body text
+	Bright lipgloss.TerminalColor // emphasized text: names, totals
+
+	ChartZero       lipgloss.TerminalColor // empty activity chart bars
+	CardBorder      lipgloss.TerminalColor // session card border in an unfocused section
+	CardBorderFocus lipgloss.TerminalColor // session card border in the focused section
+
+	// SelectBg is the selected row's background. With no color the row is
+	// drawn in reverse video instead.
+	SelectBg lipgloss.TerminalColor
+
+	// ChangedBg is the background of rows that changed in a recent
+	// refresh. With no color they are drawn bold instead.
+	ChangedBg lipgloss.TerminalColor
+}
+
+// gmorkTheme is the default dark theme, matching the web frontend's CSS
+// variables.
+var gmorkTheme = Theme{
+	Name:            "gmork",
+	Border:          lipgloss.Color("#1a1a1a"),
+	Green:           lipgloss.Color("#00ff99"),
+	Cyan:            lipgloss.Color("#00ffd5"),
+	Purple:          lipgloss.Color("#bf6fff"),
+	Orange:          lipgloss.Color("#ff8c4c"),
+	Red:             lipgloss.Color("#ff4477"),
+	Idle:            lipgloss.Color("#666666"),
+	Dim:             lipgloss.Color("#555555"),
+	Dimmer:          lipgloss.Color("#333333"),
+	Fg:              lipgloss.Color("#bbbbbb"),
+	Bright:          lipgloss.Color("#ffffff"),
+	ChartZero:       lipgloss.Color("#0a3a1a"),
+	CardBorder:      lipgloss.Color("#222222"),
+	CardBorderFocus: lipgloss.Color("#444444"),
+	SelectBg:        lipgloss.Color("#0a2a1a"),
+	ChangedBg:       lipgloss.Color("#1f1f0a"),
+}
+
+// lightTheme is for terminals with a light background.
+var lightTheme = Theme{
+	Name:            "light",
+	Border:          lipgloss.Color("#d0d0d0"),
+	Green:           lipgloss.Color("#00804a"),
+	Cyan:            lipgloss.Color("#007c89"),
+	Purple:          lipgloss.Color("#7a3fbf"),
+	Orange:          lipgloss.Color("#b85400"),
+	Red:             lipgloss.Color("#cc1f4f"),
+	Idle:            lipgloss.Color("#808080"),
+	Dim:             lipgloss.Color("#707070"),
+	Dimmer:          lipgloss.Color("#a8a8a8"),
+	Fg:              lipgloss.Color("#303030"),
+	Bright:          lipgloss.Color("#000000"),
+	ChartZero:       lipgloss.Color("#cfe8da"),
+	CardBorder:      lipgloss.Color("#dddddd"),
+	CardBorderFocus: lipgloss.Color("#999999"),
+	SelectBg:        lipgloss.Color("#d5f2e3"),
+	ChangedBg:       lipgloss.Color("#fff4c2"),
+}
+
+// highContrastTheme uses the terminal's own bright ANSI colors, so it
+// follows the terminal palette.
+var highContrastTheme = Theme{
+	Name:            "high-contrast",
+	Border:          lipgloss.Color("7"),
+	Green:           lipgloss.Color("10"),
+	Cyan:            lipgloss.Color("14"),
+	Purple:          lipgloss.Color("13"),
+	Orange:          lipgloss.Color("11"),
+	Red:             lipgloss.Color("9"),
+	Idle:            lipgloss.Color("15"),
+	Dim:             lipgloss.Color("7"),
+	Dimmer:          lipgloss.Color("7"),
+	Fg:              lipgloss.Color("15"),
+	Bright:          lipgloss.Color("15"),
+	ChartZero:       lipgloss.Color("8"),
+	CardBorder:      lipgloss.Color("7"),
+	CardBorderFocus: lipgloss.Color("15"),
+	SelectBg:        lipgloss.Color("4"),
+	ChangedBg:       lipgloss.Color("5"),
+}
+
+// monochromeTheme draws no color at all; bold and reverse video still mark
+// emphasis and the selection. It is chosen when NO_COLOR is set.
+var monochromeTheme = Theme{
+	Name:            "monochrome",
+	Border:          lipgloss.NoColor{},
+	Green:           lipgloss.NoColor{},
+	Cyan:            lipgloss.NoColor{},
+	Purple:          lipgloss.NoColor{},
+	Orange:          lipgloss.NoColor{},
+	Red:             lipgloss.NoColor{},
+	Idle:            lipgloss.NoColor{},
+	Dim:             lipgloss.NoColor{},
+	Dimmer:          lipgloss.NoColor{},
+	Fg:              lipgloss.NoColor{},
+	Bright:          lipgloss.NoColor{},
+	ChartZero:       lipgloss.NoColor{},
+	CardBorder:      lipgloss.NoColor{},
+	CardBorderFocus: lipgloss.NoColor{},
+	SelectBg:        lipgloss.NoColor{},
+	ChangedBg:       lipgloss.NoColor{},
+}
+
+var builtinThemes = map[string]Theme{
+	gmorkTheme.Name:        gmorkTheme,
+	lightTheme.Name:        lightTheme,
+	highContrastTheme.Name: highContrastTheme,
+	monochromeTheme.Name:   monochromeTheme,
+}
+
+// loadTheme resolves a theme setting:
+//
+//	"auto" or ""     monochrome if NO_COLOR is set, otherwise gmork or light
+//	                 depending on the terminal background
+//	a built-in name  gmork, light, high-contrast or monochrome
+//	a file           a path, or NAME for themes/NAME.json next to the
+//	                 config file
+//
+// A theme file is a JSON object of colors keyed by field name ("green",
+// "selectBg", ...) over a "base" theme, gmork by default. A color is a hex
+// value, an ANSI color number, or "" for none.
+func loadTheme(name, configPath string) (Theme, error) {
+	if name == "" || name == "auto" {
+		switch {
+		case os.Getenv("NO_COLOR") != "":
+			return monochromeTheme, nil
+		case lipgloss.HasDarkBackground():
+			return gmorkTheme, nil
+		default:
+			return lightTheme, nil
+		}
+	}
+	if t, ok := builtinThemes[name]; ok {
+		return t, nil
+	}
+
+	path := name
+	if !strings.ContainsRune(name, filepath.Separator) && !strings.HasSuffix(name, ".json") {
+		if configPath == "" {
+			return Theme{}, fmt.Errorf("unknown theme %q", name)
+		}
+		path = filepath.Join(filepath.Dir(configPath), "themes", name+".json")
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+		path = filepath.Join(home, path[2:])
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && path != name {
+		return Theme{}, fmt.Errorf("unknown theme %q (no %s)", name, path)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("theme: %w", err)
+	}
+	return parseTheme(name, data)
+}
+
+// parseTheme reads a theme file.
+func parseTheme(name string, data []byte) (Theme, error) {
+	var colors map[string]string
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return Theme{}, fmt.Errorf("theme %s: %w", name, err)
+	}
+	base := gmorkTheme
+	if b, ok := colors["base"]; ok {
+		if base, ok = builtinThemes[b]; !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown base %q", name, b)
+		}
+		delete(colors, "base")
+	}
+
+	t := base
+	t.Name = name
+	fields := t.colors()
+	for key, value := range colors {
+		field, ok := fields[key]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown color %q", name, key)
+		}
+		c, err := parseColor(value)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %s: %w", name, key, err)
+		}
+		*field = c
+	}
+	return t, nil
+}
+
+// colors maps theme file keys to t's fields.
+func (t *Theme) colors() map[string]*lipgloss.TerminalColor {
+	return map[string]*lipgloss.TerminalColor{
+		"border":          &t.Border,
+		"green":           &t.Green,
+		"cyan":            &t.Cyan,
+		"purple":          &t.Purple,
+		"orange":          &t.Orange,
+		"red":             &t.Red,
+		"idle":            &t.Idle,
+		"dim":             &t.Dim,
+		"dimmer":          &t.Dimmer,
+		"fg":              &t.Fg,
+		"bright":          &t.Bright,
+		"chartZero":       &t.ChartZero,
+		"cardBorder":      &t.CardBorder,
+		"cardBorderFocus": &t.CardBorderFocus,
+		"selectBg":        &t.SelectBg,
+		"changedBg":       &t.ChangedBg,
+	}
+}
+
+// parseColor accepts "#rgb", "#rrggbb", an ANSI color number 0-255, or ""
+// for no color.
+func parseColor(s string) (lipgloss.TerminalColor, error) {
+	if s == "" {
+		return lipgloss.NoColor{}, nil
+	}
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if _, err := strconv.ParseUint(hex, 16, 32); err == nil && (len(hex) == 3 || len(hex) == 6) {
+			return lipgloss.Color(s), nil
+		}
+	} else if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return nil, fmt.Errorf("invalid color %q", s)
+}
+
+// selected is the style of the selected row.
+func (t Theme) selected() lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	if _, none := t.SelectBg.(lipgloss.NoColor); none {
+		return style.Reverse(true)
+	}
+	return style.Background(t.SelectBg)
+}
+
+// changed is the style of a row that changed in a recent refresh.
+func (t Theme) changed() lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if _, none := t.ChangedBg.(lipgloss.NoColor); none {
+		return style.Bold(true)
+	}
+	return style.Background(t.ChangedBg)
+}
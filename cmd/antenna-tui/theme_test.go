@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    lipgloss.TerminalColor
+		wantErr bool
+	}{
+		{"", lipgloss.NoColor{}, false},
+		{"#fff", lipgloss.Color("#fff"), false},
+		{"#00ffd5", lipgloss.Color("#00ffd5"), false},
+		{"0", lipgloss.Color("0"), false},
+		{"255", lipgloss.Color("255"), false},
+		{"256", nil, true},
+		{"-1", nil, true},
+		{"#ffff", nil, true},
+		{"#gggggg", nil, true},
+		{"00ffd5", nil, true},
+		{"red", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseColor(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseColor(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		check   func(Theme) bool
+		wantErr string
+	}{
+		{
+			name:  "gmork base by default",
+			data:  `{"red": "#123456"}`,
+			check: func(th Theme) bool { return th.Red == lipgloss.Color("#123456") && th.Cyan == gmorkTheme.Cyan },
+		},
+		{
+			name:  "other base",
+			data:  `{"base": "light", "selectBg": ""}`,
+			check: func(th Theme) bool { return th.Cyan == lightTheme.Cyan && th.SelectBg == lipgloss.NoColor{} },
+		},
+		{name: "unknown base", data: `{"base": "solarized"}`, wantErr: `unknown base "solarized"`},
+		{name: "unknown color", data: `{"magenta": "#fff"}`, wantErr: `unknown color "magenta"`},
+		{name: "bad color", data: `{"green": "grass"}`, wantErr: `green: invalid color "grass"`},
+		{name: "not an object", data: `["#fff"]`, wantErr: "theme mine:"},
+	}
+	for _, tt := range tests {
+		th, err := parseTheme("mine", []byte(tt.data))
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error %v, want it to mention %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if th.Name != "mine" || !tt.check(th) {
+			t.Errorf("%s: got %+v", tt.name, th)
+		}
+	}
+}
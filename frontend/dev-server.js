@@ -115,13 +115,29 @@ function loadSessions() {
   return sessions;
 }
 
-function getHourlyActivity() {
-  const now = Date.now();
-  const cutoff = now - 24 * 60 * 60 * 1000;
-  const buckets = Array.from({ length: 24 }, (_, i) => {
-    const t = new Date(cutoff + (i + 1) * 3600000);
-    return { hour: t.toTimeString().slice(0, 5), messages: 0, cost: 0 };
-  });
+const BUCKETS = { '1m': 60000, '5m': 300000, '1h': 3600000, '1d': 86400000 };
+
+// Align t down to the start of its bucket in local time.
+function alignBucket(t, bucket) {
+  const d = new Date(t);
+  if (bucket === '1d') return new Date(d.getFullYear(), d.getMonth(), d.getDate()).getTime();
+  const step = BUCKETS[bucket] / 60000;
+  d.setMinutes(d.getMinutes() - (d.getMinutes() % step), 0, 0);
+  return d.getTime();
+}
+
+function nextBucket(t, bucket) {
+  if (bucket !== '1d') return t + BUCKETS[bucket];
+  const d = new Date(t);
+  return new Date(d.getFullYear(), d.getMonth(), d.getDate() + 1).getTime();
+}
+
+function getActivity(from, to, bucket) {
+  const buckets = [];
+  for (let t = alignBucket(from, bucket); t < to; t = nextBucket(t, bucket)) {
+    buckets.push({ start: new Date(t).toISOString(), startMs: t, messages: 0, cost: 0 });
+  }
+  const start = buckets.length ? buckets[0].startMs : to;
 
   const files = fs.readdirSync(SESSIONS_DIR).filter(f => f.endsWith('.jsonl'));
   for (const file of files) {
@@ -133,8 +149,9 @@ function getHourlyActivity() {
           const entry = JSON.parse(line);
           if (entry.type !== 'message' || !entry.message?.timestamp) continue;
           const ts = entry.message.timestamp;
-          if (ts < cutoff || ts > now) continue;
-          const idx = Math.min(Math.floor((ts - cutoff) / 3600000), 23);
+          if (ts < start || ts >= to) continue;
+          let idx = buckets.length - 1;
+          while (buckets[idx].startMs > ts) idx--;
           buckets[idx].messages++;
           const cost = entry.message?.usage?.cost?.total;
           if (cost) buckets[idx].cost += cost;
@@ -142,7 +159,7 @@ function getHourlyActivity() {
       }
     } catch {}
   }
-  return buckets;
+  return buckets.map(({ startMs, ...b }) => b);
 }
 
 const server = http.createServer((req, res) => {
@@ -160,8 +177,12 @@ const server = http.createServer((req, res) => {
       res.statusCode = 500;
       res.end(JSON.stringify({ error: e.message }));
     }
-  } else if (req.url === '/api/hourly') {
-    res.end(JSON.stringify(getHourlyActivity()));
+  } else if (req.url.startsWith('/api/activity')) {
+    const q = new URL(req.url, 'http://localhost').searchParams;
+    const to = Number(q.get('to')) || Date.now();
+    const from = Number(q.get('from')) || to - 23 * 3600000;
+    const bucket = BUCKETS[q.get('bucket')] ? q.get('bucket') : '1h';
+    res.end(JSON.stringify(getActivity(from, to, bucket)));
   } else {
     res.statusCode = 404;
     res.end('{}');
@@ -1,4 +1,5 @@
-import { GetDashboard, GetHourlyActivity } from '../wailsjs/go/main/App';
+import { CopySession, GetActivity, GetDashboard, GetErrors, GetForecast, GetHeatmap, GetLatency, GetRefreshInterval, GetSessionTimeline, SetAnnotation } from '../wailsjs/go/main/App';
+import { EventsOn } from '../wailsjs/runtime/runtime';
 import Chart from 'chart.js/auto';
 
 const formatCost = (cost) => {
@@ -13,16 +14,591 @@ function renderError(message) {
             <div style="font-size: 14px; color: #888; margin-bottom: 10px;">Antenna</div>
             <div style="font-size: 12px; color: #ff6b35; max-width: 400px; text-align: center;">${message}</div>
             <div style="font-size: 11px; color: #444; margin-top: 20px;">
-                Looking for: ~/.openclaw/agents/main/sessions/
+                Looking for: ~/.openclaw/agents/*/sessions/
             </div>
         </div>
     `;
 }
 
+const anomalyBadge = (s) => {
+    const anomalies = s.anomalies || [];
+    if (anomalies.length === 0) return '';
+    const title = anomalies.map(a => a.message).join('\n');
+    return `<span class="badge anomaly" title="${escapeHTML(title)}">⚠ ${anomalies.map(a => a.kind).join(',')}</span>`;
+};
+
+const stuckBadge = (s) => {
+    if (!s.stuck) return '';
+    const mins = Math.floor((Date.now() - s.lastProgressAt) / 60000);
+    const since = mins < 60 ? `${mins}m` : `${Math.floor(mins / 60)}h`;
+    return `<span class="badge stuck" title="${escapeHTML(s.stuckReason)}">◌ stuck ${since}</span>`;
+};
+
+const contextBadge = (s) => {
+    if (!s.contextWarning) return '';
+    return `<span class="badge context" title="${formatTokens(s.contextTokens)} / ${formatTokens(s.contextLimit)} tokens">◔ ${Math.round(s.contextUtilization * 100)}%</span>`;
+};
+
+const formatTokens = (n) => {
+    if (n >= 1e6) return `${(n / 1e6).toFixed(1)}M`;
+    if (n >= 1e3) return `${Math.round(n / 1e3)}k`;
+    return `${n || 0}`;
+};
+
+const contextGauge = (s) => {
+    const compactions = s.compactionCount
+        ? ` <span class="dim">· ${s.compactionCount} compactions, last ${new Date(s.lastCompactionAt).toLocaleTimeString()}</span>`
+        : '';
+    if (!s.contextLimit) return `${formatTokens(s.contextTokens)} <span class="dim">tokens (limit unknown)</span>${compactions}`;
+    const pct = Math.round(s.contextUtilization * 100);
+    const level = s.contextUtilization >= 0.95 ? 'red' : s.contextWarning ? 'orange' : 'green';
+    return `<span class="context-gauge"><span class="context-fill ${level}" style="width: ${Math.min(pct, 100)}%"></span></span>
+        <span class="${level}">${pct}%</span>
+        <span class="dim">${formatTokens(s.contextTokens)} / ${formatTokens(s.contextLimit)}</span>${compactions}`;
+};
+
+const pinBadge = (s) => s.pinned ? '<span class="badge pin" title="Pinned">★</span>' : '';
+
+const tagBadges = (s) => (s.tags || []).map(t => `<span class="badge tag">#${escapeHTML(t)}</span>`).join('');
+
+// ── Tag filter & hidden sessions ──
+
+let tagFilter = '';
+let showHidden = false;
+
+const visibleSessions = (sessions) => sessions.filter(s =>
+    (showHidden || !s.hidden) && (!tagFilter || (s.tags || []).includes(tagFilter)));
+
+function applyFilters() {
+    if (lastDashboard) updateDashboardValues(lastDashboard);
+    refreshErrors();
+}
+
+function showNotice(text) {
+    let box = document.getElementById('notices');
+    if (!box) {
+        box = document.createElement('div');
+        box.id = 'notices';
+        document.body.appendChild(box);
+    }
+    const el = document.createElement('div');
+    el.className = 'notice';
+    el.textContent = text;
+    box.appendChild(el);
+    setTimeout(() => el.remove(), 8000);
+}
+
+const escapeHTML = (text) => String(text ?? '').replace(/[&<>"']/g, c => ({
+    '&': '&amp;', '<': '&lt;', '>': '&gt;', '"': '&quot;', "'": '&#39;',
+}[c]));
+
+// ── Change highlights ──
+
+// Sessions that are new, became active or grew since the previous refresh
+// stay highlighted for a few seconds; a session that changes again while
+// highlighted keeps its earlier deltas.
+const HIGHLIGHT_MS = 5000;
+const changes = new Map();
+let sessionDelta = 0;
+let costDelta = 0;
+
+// trackChanges highlights the changes GetDashboard reports against its
+// previous load.
+function trackChanges(diff) {
+    const now = Date.now();
+    sessionDelta = diff.sessionDelta || 0;
+    costDelta = diff.costDelta || 0;
+
+    for (const [id, c] of changes) {
+        if (c.until <= now) changes.delete(id);
+    }
+    for (const c of diff.sessions || []) {
+        const merged = { new: !!c.new, becameActive: !!c.becameActive, costDelta: c.costDelta || 0, messageDelta: c.messageDelta || 0 };
+        const earlier = changes.get(c.sessionId);
+        if (earlier) {
+            merged.new = merged.new || earlier.new;
+            merged.becameActive = merged.becameActive || earlier.becameActive;
+            merged.costDelta += earlier.costDelta;
+            merged.messageDelta += earlier.messageDelta;
+        }
+        changes.set(c.sessionId, { ...merged, until: now + HIGHLIGHT_MS });
+    }
+    if (changes.size > 0) {
+        setTimeout(() => {
+            if (dashboardInitialized && lastDashboard) updateDashboardValues(lastDashboard);
+        }, HIGHLIGHT_MS);
+    }
+}
+
+const changeFor = (s) => {
+    const c = changes.get(s.sessionId);
+    return c && c.until > Date.now() ? c : null;
+};
+
+const changedClass = (s) => changeFor(s) ? ' changed' : '';
+
+const changeBadge = (s) => {
+    const c = changeFor(s);
+    if (!c) return '';
+    const parts = [];
+    if (c.new) parts.push('new');
+    else if (c.becameActive) parts.push('▲ active');
+    if (c.costDelta > 0) parts.push(`+${formatCost(c.costDelta)}`);
+    else if (c.messageDelta > 0) parts.push(`+${c.messageDelta} msgs`);
+    return parts.length > 0 ? `<span class="badge delta">${parts.join(' ')}</span>` : '';
+};
+
+function renderDeltas() {
+    const sessions = document.getElementById('stat-session-delta');
+    if (sessions) {
+        sessions.textContent = sessionDelta > 0 ? `+${sessionDelta}` : sessionDelta < 0 ? `−${-sessionDelta}` : '';
+        sessions.classList.toggle('down', sessionDelta < 0);
+    }
+    const cost = document.getElementById('stat-cost-delta');
+    if (cost) cost.textContent = costDelta > 0 ? `+${formatCost(costDelta)}` : '';
+}
+
+let errorsOpen = false;
+
+async function toggleErrors() {
+    errorsOpen = !errorsOpen;
+    const panel = document.getElementById('errors-panel');
+    if (!panel) return;
+    panel.style.display = errorsOpen ? '' : 'none';
+    if (errorsOpen) await refreshErrors();
+}
+
+async function refreshErrors() {
+    const panel = document.getElementById('errors-panel');
+    if (!panel || !errorsOpen) return;
+    let events = [];
+    try {
+        events = await GetErrors();
+    } catch (e) {
+        console.error('Failed to get errors:', e);
+    }
+    if (!Array.isArray(events)) events = [];
+    const byId = new Map(((lastDashboard && lastDashboard.sessions) || []).map(s => [s.sessionId, s]));
+    events = events.filter(e => !byId.has(e.sessionId) || visibleSessions([byId.get(e.sessionId)]).length > 0);
+    panel.innerHTML = `
+        <div class="section-header">
+            <span class="section-title red">Errors</span>
+            <span class="count">${events.length}</span>
+        </div>
+        <div class="rows scrollable">
+            ${events.length > 0 ? events.map(e => `
+            <div class="row error-row">
+                <span class="error-time">${new Date(e.at).toLocaleString('en-US', { month: 'short', day: 'numeric', hour: '2-digit', minute: '2-digit', second: '2-digit' })}</span>
+                <span class="session-name">${escapeHTML(e.sessionName)}</span>
+                <span class="model">${escapeHTML(e.model)}</span>
+                <span class="error-kind ${escapeHTML(e.kind)}">${escapeHTML(e.kind)}</span>
+                <span class="error-message">${escapeHTML(e.message)}</span>
+            </div>
+            `).join('') : '<div class="empty">No errors</div>'}
+        </div>
+    `;
+}
+
+const rowsHTML = (items, dim) => items.map(s => `
+    <div class="row${dim ? ' dim' : ''}${changedClass(s)}" data-session-id="${s.sessionId}">
+        <span class="session-name">${pinBadge(s)}${escapeHTML(s.name || 'unnamed')}</span>
+        <span class="session-id">${s.sessionId || ''}</span>
+        ${!dim ? `<span class="model">${s.model || ''}</span>` : ''}
+        <span class="msgs">${s.messageCount || 0}</span>
+        ${!dim ? `<span class="cost green">${formatCost(s.todayCost)}</span>` : ''}
+        <span class="cost">${formatCost(s.totalCost)}</span>
+        ${changeBadge(s)}
+        ${contextBadge(s)}
+        ${anomalyBadge(s)}
+        ${tagBadges(s)}
+    </div>
+`).join('');
+
+const cardsHTML = (items) => items.length > 0 ? items.map(s => `
+    <div class="card${changedClass(s)}" data-session-id="${s.sessionId}">
+        <div class="card-header">
+            <span class="card-name">${pinBadge(s)}${escapeHTML(s.name || 'unnamed')}</span>
+            ${changeBadge(s)}
+            ${stuckBadge(s)}
+            ${contextBadge(s)}
+            ${anomalyBadge(s)}
+            ${tagBadges(s)}
+            ${s.isActive ? '<span class="live-dot small"></span>' : ''}
+        </div>
+        <div class="card-meta">
+            <span>${s.messageCount || 0} msgs</span>
+            <span>${formatCost(s.totalCost)}</span>
+        </div>
+    </div>
+`).join('') : '<div class="empty">None</div>';
+
+const formatMillis = (ms) => {
+    if (ms < 1000) return `${Math.round(ms)}ms`;
+    if (ms < 60000) return `${(ms / 1000).toFixed(1)}s`;
+    const secs = Math.round(ms / 1000);
+    return `${Math.floor(secs / 60)}m${String(secs % 60).padStart(2, '0')}s`;
+};
+
+const formatPercentiles = (p, format) => {
+    if (!p || !p.count) return '—';
+    return `p50 ${format(p.p50)} · p95 ${format(p.p95)} · p99 ${format(p.p99)} (n=${p.count})`;
+};
+
+// ── Forecast ──
+
+async function refreshForecast() {
+    const group = document.getElementById('forecast-group');
+    const value = document.getElementById('stat-month-cost');
+    if (!group || !value) return;
+    let fc = null;
+    try {
+        fc = await GetForecast();
+    } catch (e) {
+        console.error('Failed to get forecast:', e);
+        return;
+    }
+    const band = (p) => `${formatCost(p.expected)} (${formatCost(p.low)}–${formatCost(p.high)}, so far ${formatCost(p.actual)})`;
+    const breakdown = (parts) => Object.entries(parts || {})
+        .sort((a, b) => b[1].expected - a[1].expected)
+        .map(([key, p]) => `  ${key}: ${formatCost(p.expected)}`)
+        .join('\n');
+    value.textContent = formatCost(fc.endOfMonth.expected);
+    group.classList.toggle('over-budget', !!fc.overBudget);
+    const today = document.getElementById('today-group');
+    if (today) {
+        today.classList.toggle('over-budget', !!fc.overDailyBudget);
+        today.title = fc.dailyBudget
+            ? `End of day: ${band(fc.endOfDay)}\nBudget: ${formatCost(fc.dailyBudget)}${fc.overDailyBudget ? ' — projected to exceed' : ''}`
+            : `End of day: ${band(fc.endOfDay)}`;
+    }
+    group.title = [
+        `End of day: ${band(fc.endOfDay)}`,
+        `End of week: ${band(fc.endOfWeek)}`,
+        `End of month: ${band(fc.endOfMonth)}`,
+        fc.monthlyBudget ? `Budget: ${formatCost(fc.monthlyBudget)}${fc.overBudget ? ' — projected to exceed' : ''}` : '',
+        `Based on ${fc.historyDays} days, ${formatCost(fc.dailyMean)}/day`,
+        'By kind:',
+        breakdown(fc.byKind),
+        'By model:',
+        breakdown(fc.byModel),
+    ].filter(Boolean).join('\n');
+}
+
+// ── Heatmap Panel ──
+
+const HEATMAP_LOOKBACKS = [7, 14, 30, 90];
+let heatmapOpen = false;
+let heatmapDays = 7;
+let heatmapCost = false;
+
+async function toggleHeatmap() {
+    heatmapOpen = !heatmapOpen;
+    const panel = document.getElementById('heatmap-panel');
+    if (!panel) return;
+    panel.style.display = heatmapOpen ? '' : 'none';
+    if (heatmapOpen) await refreshHeatmap();
+}
+
+async function refreshHeatmap() {
+    if (!heatmapOpen) return;
+    const panel = document.getElementById('heatmap-panel');
+    if (!panel) return;
+    let hm = null;
+    try {
+        hm = await GetHeatmap(heatmapDays);
+    } catch (e) {
+        console.error('Failed to get heatmap:', e);
+        return;
+    }
+    if (!hm || !hm.messages) {
+        panel.innerHTML = '<div class="empty">No activity data</div>';
+        return;
+    }
+
+    const values = heatmapCost ? hm.cost : hm.messages;
+    const max = heatmapCost ? hm.maxCost : hm.maxMessages;
+    const days = [1, 2, 3, 4, 5, 6, 0];
+    const dayNames = ['Sun', 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat'];
+    const hours = Array.from({ length: 24 }, (_, h) => h);
+    const cell = (day, h) => {
+        const v = values[day][h];
+        const alpha = max > 0 && v > 0 ? (0.15 + 0.85 * v / max).toFixed(2) : 0;
+        const label = heatmapCost ? formatCost(v) : `${v} messages`;
+        return `<span class="heatmap-cell${heatmapCost ? ' cost' : ''}" style="--alpha: ${alpha}" title="${dayNames[day]} ${String(h).padStart(2, '0')}:00 · ${label}"></span>`;
+    };
+
+    panel.innerHTML = `
+        <div class="heatmap-controls">
+            ${HEATMAP_LOOKBACKS.map(d => `<span class="heatmap-option${d === heatmapDays ? ' selected' : ''}" data-days="${d}">${d}d</span>`).join('')}
+            <span class="heatmap-option${heatmapCost ? '' : ' selected'}" data-metric="messages">messages</span>
+            <span class="heatmap-option${heatmapCost ? ' selected' : ''}" data-metric="cost">cost</span>
+        </div>
+        <div class="heatmap-grid">
+            <span></span>
+            ${hours.map(h => `<span class="heatmap-hour">${h % 3 === 0 ? String(h).padStart(2, '0') : ''}</span>`).join('')}
+            ${days.map(day => `<span class="heatmap-day">${dayNames[day]}</span>${hours.map(h => cell(day, h)).join('')}`).join('')}
+        </div>
+    `;
+    panel.querySelectorAll('[data-days]').forEach(el => el.addEventListener('click', () => {
+        heatmapDays = Number(el.dataset.days);
+        refreshHeatmap();
+    }));
+    panel.querySelectorAll('[data-metric]').forEach(el => el.addEventListener('click', () => {
+        heatmapCost = el.dataset.metric === 'cost';
+        refreshHeatmap();
+    }));
+}
+
+// ── Detail Panel ──
+
+let lastDashboard = null;
+let selectedSessionId = null;
+let selectedTimeline = null;
+
+function openDetail(sessionId) {
+    selectedSessionId = sessionId;
+    selectedTimeline = null;
+    renderDetailPanel();
+    refreshTimeline();
+}
+
+async function refreshTimeline() {
+    if (!selectedSessionId) return;
+    try {
+        selectedTimeline = await GetSessionTimeline(selectedSessionId);
+    } catch (e) {
+        console.error('Failed to get timeline:', e);
+        selectedTimeline = null;
+    }
+    renderDetailPanel();
+}
+
+const formatDuration = (ms) => {
+    const mins = Math.floor(ms / 60000);
+    if (mins < 1) return `${Math.floor(ms / 1000)}s`;
+    if (mins < 60) return `${mins}m`;
+    if (mins < 1440) return `${Math.floor(mins / 60)}h${String(mins % 60).padStart(2, '0')}m`;
+    return `${Math.floor(mins / 1440)}d${Math.floor(mins / 60) % 24}h`;
+};
+
+function timelineHTML(tl) {
+    if (!tl || !tl.buckets || tl.buckets.length === 0) return '<div class="empty">No messages</div>';
+    // Markers share the bars' axis, which runs from the first bucket's start
+    // to the last bucket's end.
+    const bucketMs = { '1m': 60000, '5m': 300000, '1h': 3600000, '24h': 86400000 }[tl.bucket] || 60000;
+    const axisStart = Date.parse(tl.buckets[0].start);
+    const span = Date.parse(tl.buckets[tl.buckets.length - 1].start) + bucketMs - axisStart;
+    const maxMsgs = Math.max(...tl.buckets.map(b => b.messages), 1);
+    const bars = tl.buckets.map(b => {
+        const at = new Date(b.start);
+        const title = `${at.toLocaleTimeString()} · ${b.messages} messages · ${formatCost(b.cost)}`;
+        return `<span class="timeline-bar" style="height: ${Math.round(b.messages / maxMsgs * 100)}%" title="${title}"></span>`;
+    }).join('');
+    const markers = (tl.toolCalls || []).map(t => {
+        const left = ((t.at - axisStart) / span * 100).toFixed(2);
+        return `<span class="timeline-marker${t.failed ? ' failed' : ''}" style="left: ${left}%" title="${escapeHTML(t.name)}${t.failed ? ' (failed)' : ''}"></span>`;
+    }).join('');
+    const start = new Date(tl.start), end = new Date(tl.end);
+    return `
+        <div class="timeline-header">
+            ${start.toLocaleTimeString()} → ${end.toLocaleTimeString()}
+            <span class="dim">· ${formatDuration(tl.end - tl.start)} · ${tl.bucket} buckets · ${(tl.toolCalls || []).length} tool calls</span>
+        </div>
+        <div class="timeline-bars">${bars}</div>
+        <div class="timeline-markers">${markers}</div>`;
+}
+
+function closeDetail() {
+    selectedSessionId = null;
+    renderDetailPanel();
+}
+
+function renderDetailPanel() {
+    const panel = document.getElementById('detail-panel');
+    if (!panel) return;
+    const sessions = (lastDashboard && lastDashboard.sessions) || [];
+    const s = sessions.find(x => x.sessionId === selectedSessionId);
+    if (!s) {
+        panel.style.display = 'none';
+        return;
+    }
+    // Don't throw away annotation edits in progress on a background refresh.
+    const form = document.getElementById('annotation-form');
+    if (form && form.dataset.for === s.sessionId && form.dataset.dirty) return;
+    const field = (label, value) => `
+        <div class="detail-field">
+            <span class="detail-label">${label}</span>
+            <span class="detail-value">${value}</span>
+        </div>`;
+    const status = s.stuck ? `<span class="orange">◌ Stuck</span> <span class="dim">${escapeHTML(s.stuckReason)}</span>`
+        : s.isActive ? '<span class="green">● Active</span>' : '<span class="dim">○ Inactive</span>';
+    const lat = s.latency;
+    panel.innerHTML = `
+        <div class="detail-header">
+            <span class="detail-title">${escapeHTML(s.name)}</span>
+            <span class="detail-close" id="detail-close">✕</span>
+        </div>
+        ${field('Status', status)}
+        ${field('Kind', escapeHTML(s.kind))}
+        ${field('Model', escapeHTML(s.model || 'unknown'))}
+        ${field('Messages', s.messageCount || 0)}
+        ${field('Today', `<span class="green">${formatCost(s.todayCost)}</span>`)}
+        ${field('Total', formatCost(s.totalCost))}
+        ${field('Updated', new Date(s.updatedAt).toLocaleString())}
+        ${field('Session', `<span class="dim">${s.sessionId}</span>`)}
+        ${s.originalName ? field('OpenClaw', `<span class="dim">${escapeHTML(s.originalName)}</span>`) : ''}
+        ${s.lastError ? field('Errors', `<span class="red">${s.errorCount}</span> <span class="dim">last ${s.lastError.kind}:</span> ${escapeHTML(s.lastError.message)}`) : ''}
+        ${s.contextLimit || s.contextTokens ? field('Context', contextGauge(s)) : ''}
+        ${lat ? field('Latency', formatPercentiles(lat.turnLatency, formatMillis)) : ''}
+        ${lat ? field('Tool time', formatPercentiles(lat.toolTime, formatMillis)) : ''}
+        ${lat ? field('Throughput', formatPercentiles(lat.tokensPerSec, v => `${Math.round(v)} tok/s`)) : ''}
+        ${(s.anomalies || []).map(a => field(`<span class="red">⚠ ${a.kind}</span>`, escapeHTML(a.message))).join('')}
+        <div class="detail-copy">
+            <span class="dim">Copy</span>
+            <button data-copy="id">ID</button>
+            <button data-copy="path">Path</button>
+            <button data-copy="summary">Summary</button>
+            <button data-copy="markdown">Markdown</button>
+        </div>
+        <div class="detail-section">Annotations</div>
+        <form class="annotation-form" id="annotation-form" data-for="${s.sessionId}">
+            <input name="name" placeholder="${escapeHTML(s.originalName || s.name)}" value="${s.originalName ? escapeHTML(s.name) : ''}">
+            <input name="tags" placeholder="tags, comma separated" value="${escapeHTML((s.tags || []).join(', '))}">
+            <textarea name="note" rows="3" placeholder="note">${escapeHTML(s.note || '')}</textarea>
+            <div class="annotation-flags">
+                <label><input type="checkbox" name="pinned"${s.pinned ? ' checked' : ''}> pinned</label>
+                <label><input type="checkbox" name="hidden"${s.hidden ? ' checked' : ''}> hidden</label>
+                <button type="submit">Save</button>
+            </div>
+        </form>
+        <div class="detail-section">Timeline</div>
+        ${timelineHTML(selectedTimeline && selectedTimeline.sessionId === s.sessionId ? selectedTimeline : null)}
+    `;
+    panel.style.display = '';
+}
+
+async function saveAnnotation(form) {
+    const tags = form.tags.value.split(/[,\s]+/).map(t => t.replace(/^#/, '')).filter(Boolean);
+    try {
+        await SetAnnotation(selectedSessionId, {
+            name: form.name.value.trim(),
+            tags,
+            note: form.note.value.trim(),
+            pinned: form.pinned.checked,
+            hidden: form.hidden.checked,
+        });
+    } catch (e) {
+        showNotice(`Annotation not saved: ${e.message || e}`);
+        return;
+    }
+    refresh();
+}
+
+const copyLabels = { id: 'Session ID', path: 'Transcript path', summary: 'Summary', markdown: 'Markdown' };
+
+async function copySession(format) {
+    try {
+        await CopySession(selectedSessionId, format);
+    } catch (e) {
+        showNotice(`Not copied: ${e.message || e}`);
+        return;
+    }
+    showNotice(`${copyLabels[format]} copied`);
+}
+
+document.addEventListener('input', (e) => {
+    const form = e.target.closest('#annotation-form');
+    if (form) form.dataset.dirty = 'true';
+});
+
+document.addEventListener('submit', (e) => {
+    if (e.target.id !== 'annotation-form') return;
+    e.preventDefault();
+    saveAnnotation(e.target);
+});
+
+document.addEventListener('click', (e) => {
+    if (e.target.closest('#detail-close')) {
+        closeDetail();
+        return;
+    }
+    const copy = e.target.closest('[data-copy]');
+    if (copy) {
+        copySession(copy.dataset.copy);
+        return;
+    }
+    const row = e.target.closest('[data-session-id]');
+    if (row) openDetail(row.dataset.sessionId);
+});
+
+// ── Latency Panel ──
+
+let latencyOpen = false;
+let latencyChart = null;
+
+async function toggleLatency() {
+    latencyOpen = !latencyOpen;
+    const panel = document.getElementById('latency-panel');
+    if (!panel) return;
+    panel.style.display = latencyOpen ? '' : 'none';
+    if (latencyOpen) await refreshLatency();
+}
+
+async function refreshLatency() {
+    if (!latencyOpen) return;
+    const canvas = document.getElementById('latencyChart');
+    if (!canvas) return;
+    let report = null;
+    try {
+        report = await GetLatency();
+    } catch (e) {
+        console.error('Failed to get latency:', e);
+        return;
+    }
+    const models = (report && report.models) || [];
+    const labels = models.map(m => m.key);
+    const secs = (pick) => models.map(m => Math.round(pick(m.turnLatency) / 100) / 10);
+
+    if (latencyChart) {
+        latencyChart.data.labels = labels;
+        latencyChart.data.datasets[0].data = secs(p => p.p50);
+        latencyChart.data.datasets[1].data = secs(p => p.p95);
+        latencyChart.data.datasets[2].data = secs(p => p.p99);
+        latencyChart.update('none');
+        return;
+    }
+
+    const font = { family: "'JetBrains Mono', monospace", size: 10 };
+    latencyChart = new Chart(canvas.getContext('2d'), {
+        type: 'bar',
+        data: {
+            labels,
+            datasets: [
+                { label: 'p50', data: secs(p => p.p50), backgroundColor: 'rgba(0, 255, 153, 0.6)', borderRadius: 2 },
+                { label: 'p95', data: secs(p => p.p95), backgroundColor: 'rgba(0, 255, 153, 0.25)', borderRadius: 2 },
+                { label: 'p99', data: secs(p => p.p99), backgroundColor: 'rgba(191, 111, 255, 0.5)', borderRadius: 2 },
+            ]
+        },
+        options: {
+            indexAxis: 'y',
+            responsive: true,
+            maintainAspectRatio: false,
+            plugins: {
+                legend: { position: 'top', align: 'end', labels: { color: '#555', font, boxWidth: 12, boxHeight: 2 } },
+                tooltip: { callbacks: { label: (ctx) => ` ${ctx.dataset.label}: ${ctx.parsed.x}s` } },
+            },
+            scales: {
+                x: { ticks: { color: '#555', font, callback: (v) => `${v}s` }, grid: { color: 'rgba(255,255,255,0.03)' } },
+                y: { ticks: { color: '#888', font }, grid: { display: false } },
+            }
+        }
+    });
+}
+
 let dashboardInitialized = false;
 
 function updateDashboardValues(data) {
-    const sessions = data.sessions || [];
+    const sessions = visibleSessions(data.sessions || []);
     const active = sessions.filter(s => s.kind === 'main' && s.isActive);
     const idle = sessions.filter(s => s.kind === 'main' && !s.isActive);
     const subs = sessions.filter(s => s.kind === 'subagent');
@@ -34,6 +610,7 @@ function updateDashboardValues(data) {
         'stat-active-count': active.length,
         'stat-sub-count': subs.length,
         'stat-cron-count': crons.length,
+        'stat-error-count': data.errorCount || 0,
         'stat-today-cost': formatCost(data.todayCost),
         'stat-total-cost': formatCost(data.totalCost),
     };
@@ -41,39 +618,15 @@ function updateDashboardValues(data) {
         const el = document.getElementById(id);
         if (el) el.textContent = val;
     }
-
-    // Update session rows
-    const renderRows = (items, dim) => items.map(s => `
-        <div class="row${dim ? ' dim' : ''}">
-            <span class="session-name">${s.name || 'unnamed'}</span>
-            <span class="session-id">${s.sessionId || ''}</span>
-            ${!dim ? `<span class="model">${s.model || ''}</span>` : ''}
-            <span class="msgs">${s.messageCount || 0}</span>
-            ${!dim ? `<span class="cost green">${formatCost(s.todayCost)}</span>` : ''}
-            <span class="cost">${formatCost(s.totalCost)}</span>
-        </div>
-    `).join('');
-
-    const renderCards = (items) => items.length > 0 ? items.map(s => `
-        <div class="card">
-            <div class="card-header">
-                <span class="card-name">${s.name || 'unnamed'}</span>
-                ${s.isActive ? '<span class="live-dot small"></span>' : ''}
-            </div>
-            <div class="card-meta">
-                <span>${s.messageCount || 0} msgs</span>
-                <span>${formatCost(s.totalCost)}</span>
-            </div>
-        </div>
-    `).join('') : '<div class="empty">None</div>';
+    renderDeltas();
 
     const el = (id) => document.getElementById(id);
-    if (el('active-rows')) el('active-rows').innerHTML = renderRows(active, false);
-    if (el('idle-rows')) el('idle-rows').innerHTML = renderRows(idle, true);
+    if (el('active-rows')) el('active-rows').innerHTML = rowsHTML(active, false);
+    if (el('idle-rows')) el('idle-rows').innerHTML = rowsHTML(idle, true);
     if (el('idle-count')) el('idle-count').textContent = idle.length;
-    if (el('sub-rows')) el('sub-rows').innerHTML = renderCards(subs);
+    if (el('sub-rows')) el('sub-rows').innerHTML = cardsHTML(subs);
     if (el('sub-count')) el('sub-count').textContent = subs.length;
-    if (el('cron-rows')) el('cron-rows').innerHTML = renderCards(crons);
+    if (el('cron-rows')) el('cron-rows').innerHTML = cardsHTML(crons);
     if (el('cron-count')) el('cron-count').textContent = crons.length;
 
     // Show/hide active section
@@ -94,20 +647,20 @@ function renderDashboard(data) {
         return;
     }
 
-    const sessions = data.sessions || [];
+    const sessions = visibleSessions(data.sessions || []);
     const active = sessions.filter(s => s.kind === 'main' && s.isActive);
     const idle = sessions.filter(s => s.kind === 'main' && !s.isActive);
     const subs = sessions.filter(s => s.kind === 'subagent');
     const crons = sessions.filter(s => s.kind === 'cron');
 
-    if (sessions.length === 0) {
+    if ((data.sessions || []).length === 0) {
         document.getElementById('app').innerHTML = `
             <div style="display: flex; flex-direction: column; align-items: center; justify-content: center; height: 100vh; color: #666; font-family: 'JetBrains Mono', monospace;">
                 <div style="font-size: 48px; margin-bottom: 20px;">📡</div>
                 <div style="font-size: 14px; color: #888; margin-bottom: 10px;">Antenna</div>
                 <div style="font-size: 12px; color: #555;">No sessions found</div>
                 <div style="font-size: 11px; color: #444; margin-top: 20px;">
-                    Looking in: ~/.openclaw/agents/main/sessions/
+                    Looking in: ~/.openclaw/agents/*/sessions/
                 </div>
             </div>
         `;
@@ -124,6 +677,7 @@ function renderDashboard(data) {
                 </div>
                 <div class="stat-group">
                     <span class="stat-value big" id="stat-total-count">${data.totalCount || 0}</span>
+                    <span class="delta" id="stat-session-delta"></span>
                     <span class="label">sessions</span>
                 </div>
                 <div class="stat-group">
@@ -141,17 +695,52 @@ function renderDashboard(data) {
                     <span class="stat-value orange" id="stat-cron-count">${crons.length}</span>
                     <span class="label">cron</span>
                 </div>
+                <div class="stat-group clickable" id="errors-toggle" title="Show errors">
+                    <span class="dot red"></span>
+                    <span class="stat-value red" id="stat-error-count">${data.errorCount || 0}</span>
+                    <span class="label">errors</span>
+                </div>
+                <div class="stat-group clickable" id="latency-toggle" title="Show latency by model">
+                    <span class="label">latency</span>
+                </div>
+                <div class="stat-group clickable" id="heatmap-toggle" title="Show activity by weekday and hour">
+                    <span class="label">heatmap</span>
+                </div>
+                <div class="stat-group">
+                    <input class="tag-filter" id="tag-filter" placeholder="#tag" value="${escapeHTML(tagFilter)}">
+                    <label class="label clickable" title="Include hidden sessions">
+                        <input type="checkbox" id="show-hidden"${showHidden ? ' checked' : ''}> hidden
+                    </label>
+                </div>
                 <div class="spacer"></div>
-                <div class="cost-group">
+                <div class="cost-group" id="today-group">
                     <div class="cost-label">Today</div>
                     <div class="cost-value green" id="stat-today-cost">${formatCost(data.todayCost)}</div>
                 </div>
+                <div class="cost-group" id="forecast-group">
+                    <div class="cost-label">Month →</div>
+                    <div class="cost-value" id="stat-month-cost">—</div>
+                </div>
                 <div class="cost-group">
-                    <div class="cost-label">Total</div>
+                    <div class="cost-label">Total <span class="delta" id="stat-cost-delta"></span></div>
                     <div class="cost-value" id="stat-total-cost">${formatCost(data.totalCost)}</div>
                 </div>
             </div>
 
+            <!-- Errors -->
+            <div class="errors-panel" id="errors-panel" style="display:none"></div>
+
+            <!-- Latency -->
+            <div class="latency-panel" id="latency-panel" style="display:none">
+                <canvas id="latencyChart"></canvas>
+            </div>
+
+            <!-- Heatmap -->
+            <div class="heatmap-panel" id="heatmap-panel" style="display:none"></div>
+
+            <!-- Detail -->
+            <div class="detail-panel" id="detail-panel" style="display:none"></div>
+
             <!-- Activity Chart -->
             <div class="chart-container">
                 <canvas id="activityChart"></canvas>
@@ -167,16 +756,7 @@ function renderDashboard(data) {
                             <span class="section-title green">Active</span>
                         </div>
                         <div class="rows" id="active-rows">
-                            ${active.map(s => `
-                            <div class="row">
-                                <span class="session-name">${s.name || 'unnamed'}</span>
-                                <span class="session-id">${s.sessionId || ''}</span>
-                                <span class="model">${s.model || ''}</span>
-                                <span class="msgs">${s.messageCount || 0}</span>
-                                <span class="cost green">${formatCost(s.todayCost)}</span>
-                                <span class="cost">${formatCost(s.totalCost)}</span>
-                            </div>
-                            `).join('')}
+                            ${rowsHTML(active, false)}
                         </div>
                     </div>
                     
@@ -187,14 +767,7 @@ function renderDashboard(data) {
                             <span class="count" id="idle-count">${idle.length}</span>
                         </div>
                         <div class="rows scrollable" id="idle-rows">
-                            ${idle.map(s => `
-                            <div class="row dim">
-                                <span class="session-name">${s.name || 'unnamed'}</span>
-                                <span class="session-id">${s.sessionId || ''}</span>
-                                <span class="msgs">${s.messageCount || 0}</span>
-                                <span class="cost">${formatCost(s.totalCost)}</span>
-                            </div>
-                            `).join('')}
+                            ${rowsHTML(idle, true)}
                         </div>
                     </div>
                 </div>
@@ -208,18 +781,7 @@ function renderDashboard(data) {
                             <span class="count purple" id="sub-count">${subs.length}</span>
                         </div>
                         <div class="rows scrollable" id="sub-rows">
-                            ${subs.length > 0 ? subs.map(s => `
-                            <div class="card">
-                                <div class="card-header">
-                                    <span class="card-name">${s.name || 'unnamed'}</span>
-                                    ${s.isActive ? '<span class="live-dot small"></span>' : ''}
-                                </div>
-                                <div class="card-meta">
-                                    <span>${s.messageCount || 0} msgs</span>
-                                    <span>${formatCost(s.totalCost)}</span>
-                                </div>
-                            </div>
-                            `).join('') : '<div class="empty">None</div>'}
+                            ${cardsHTML(subs)}
                         </div>
                     </div>
 
@@ -230,18 +792,7 @@ function renderDashboard(data) {
                             <span class="count orange" id="cron-count">${crons.length}</span>
                         </div>
                         <div class="rows scrollable" id="cron-rows">
-                            ${crons.length > 0 ? crons.map(s => `
-                            <div class="card">
-                                <div class="card-header">
-                                    <span class="card-name">${s.name || 'unnamed'}</span>
-                                    ${s.isActive ? '<span class="live-dot small"></span>' : ''}
-                                </div>
-                                <div class="card-meta">
-                                    <span>${s.messageCount || 0} msgs</span>
-                                    <span>${formatCost(s.totalCost)}</span>
-                                </div>
-                            </div>
-                            `).join('') : '<div class="empty">None</div>'}
+                            ${cardsHTML(crons)}
                         </div>
                     </div>
                 </div>
@@ -249,6 +800,18 @@ function renderDashboard(data) {
         </div>
     `;
 
+    document.getElementById('errors-toggle').addEventListener('click', toggleErrors);
+    document.getElementById('latency-toggle').addEventListener('click', toggleLatency);
+    document.getElementById('heatmap-toggle').addEventListener('click', toggleHeatmap);
+    document.getElementById('tag-filter').addEventListener('input', (e) => {
+        tagFilter = e.target.value.trim().replace(/^#/, '');
+        applyFilters();
+    });
+    document.getElementById('show-hidden').addEventListener('change', (e) => {
+        showHidden = e.target.checked;
+        applyFilters();
+    });
+
     dashboardInitialized = true;
 }
 
@@ -259,7 +822,7 @@ function renderActivityChart(data) {
     if (!canvas) return;
     const ctx = canvas.getContext('2d');
 
-    const labels = data.map(b => b.hour);
+    const labels = data.map(b => `${String(new Date(b.start).getHours()).padStart(2, '0')}:00`);
     const messages = data.map(b => b.messages);
     const costs = data.map(b => Math.round(b.cost * 100) / 100);
 
@@ -386,12 +949,21 @@ function renderActivityChart(data) {
 async function refresh() {
     try {
         const data = await GetDashboard();
+        if (data && data.changes) trackChanges(data.changes);
+        lastDashboard = data;
         renderDashboard(data);
+        renderDetailPanel();
+        refreshErrors();
+        refreshLatency();
+        refreshTimeline();
+        refreshHeatmap();
+        refreshForecast();
         try {
-            const hourly = await GetHourlyActivity();
-            renderActivityChart(hourly);
+            const now = Date.now();
+            const activity = await GetActivity(now - 23 * 3600000, now, '1h', {});
+            renderActivityChart(activity);
         } catch (e) {
-            console.error('Failed to get hourly activity:', e);
+            console.error('Failed to get activity:', e);
         }
     } catch (e) {
         console.error('Failed to get dashboard:', e);
@@ -399,8 +971,24 @@ async function refresh() {
     }
 }
 
-// Initial load
-refresh();
+// Anomalies are pushed by the backend as they are first detected
+if (window.runtime) {
+    EventsOn('anomaly', (ev) => {
+        showNotice(`⚠ ${ev.name}: ${ev.anomaly.message}`);
+    });
+}
+
+// Refresh on the configured interval, re-read each time so config file
+// changes apply without a restart
+async function refreshLoop() {
+    await refresh();
+    let interval = 5000;
+    try {
+        interval = (await GetRefreshInterval()) || interval;
+    } catch (e) {
+        console.error('Failed to get refresh interval:', e);
+    }
+    setTimeout(refreshLoop, interval);
+}
 
-// Auto-refresh every 5 seconds
-setInterval(refresh, 5000);
+refreshLoop();
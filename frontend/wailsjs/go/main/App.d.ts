@@ -2,6 +2,22 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
-export function GetDashboard():Promise<main.DashboardData>;
+export function CopySession(arg1:string,arg2:string):Promise<void>;
 
-export function GetHourlyActivity():Promise<Array<main.HourlyBucket>>;
+export function GetActivity(arg1:number,arg2:number,arg3:string,arg4:main.ActivityFilter):Promise<Array<main.ActivityBucket>>;
+
+export function GetDashboard():Promise<main.DashboardUpdate>;
+
+export function GetErrors():Promise<Array<main.ErrorEvent>>;
+
+export function GetForecast():Promise<main.Forecast>;
+
+export function GetHeatmap(arg1:number):Promise<main.Heatmap>;
+
+export function GetLatency():Promise<main.LatencyReport>;
+
+export function GetRefreshInterval():Promise<number>;
+
+export function GetSessionTimeline(arg1:string):Promise<main.SessionTimeline>;
+
+export function SetAnnotation(arg1:string,arg2:main.Annotation):Promise<void>;
@@ -4,12 +4,52 @@
 
 const isBrowser = !window['go'];
 
+export function CopySession(arg1, arg2) {
+  if (isBrowser) return Promise.reject(new Error('copying needs the desktop app'));
+  return window['go']['main']['App']['CopySession'](arg1, arg2);
+}
+
+export function GetActivity(arg1, arg2, arg3, arg4) {
+  if (isBrowser) return fetch(`/api/activity?from=${arg1}&to=${arg2}&bucket=${arg3}`).then(r => r.json());
+  return window['go']['main']['App']['GetActivity'](arg1, arg2, arg3, arg4);
+}
+
 export function GetDashboard() {
   if (isBrowser) return fetch('/api/dashboard').then(r => r.json());
   return window['go']['main']['App']['GetDashboard']();
 }
 
-export function GetHourlyActivity() {
-  if (isBrowser) return fetch('/api/hourly').then(r => r.json());
-  return window['go']['main']['App']['GetHourlyActivity']();
+export function GetErrors() {
+  if (isBrowser) return fetch('/api/errors').then(r => r.json());
+  return window['go']['main']['App']['GetErrors']();
+}
+
+export function GetForecast() {
+  if (isBrowser) return fetch('/api/forecast').then(r => r.json());
+  return window['go']['main']['App']['GetForecast']();
+}
+
+export function GetHeatmap(arg1) {
+  if (isBrowser) return fetch(`/api/heatmap?days=${arg1}`).then(r => r.json());
+  return window['go']['main']['App']['GetHeatmap'](arg1);
+}
+
+export function GetLatency() {
+  if (isBrowser) return fetch('/api/latency').then(r => r.json());
+  return window['go']['main']['App']['GetLatency']();
+}
+
+export function GetRefreshInterval() {
+  if (isBrowser) return Promise.resolve(5000);
+  return window['go']['main']['App']['GetRefreshInterval']();
+}
+
+export function GetSessionTimeline(arg1) {
+  if (isBrowser) return fetch(`/api/timeline?session=${encodeURIComponent(arg1)}`).then(r => r.json());
+  return window['go']['main']['App']['GetSessionTimeline'](arg1);
+}
+
+export function SetAnnotation(arg1, arg2) {
+  if (isBrowser) return fetch(`/api/annotations/${encodeURIComponent(arg1)}`, { method: 'PUT', body: JSON.stringify(arg2) }).then(r => r.json());
+  return window['go']['main']['App']['SetAnnotation'](arg1, arg2);
 }
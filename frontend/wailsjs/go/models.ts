@@ -1,15 +1,354 @@
 export namespace main {
 	
+	export class ActivityBucket {
+	    // Go type: time
+	    start: any;
+	    messages: number;
+	    cost: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityBucket(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = this.convertValues(source["start"], null);
+	        this.messages = source["messages"];
+	        this.cost = source["cost"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ActivityFilter {
+	    sessionId?: string;
+	    kind?: string;
+	    agent?: string;
+	    model?: string;
+	    tag?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.kind = source["kind"];
+	        this.agent = source["agent"];
+	        this.model = source["model"];
+	        this.tag = source["tag"];
+	    }
+	}
+	export class Annotation {
+	    name?: string;
+	    tags?: string[];
+	    note?: string;
+	    pinned?: boolean;
+	    hidden?: boolean;
+	    updatedAt?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Annotation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	        this.pinned = source["pinned"];
+	        this.hidden = source["hidden"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class Anomaly {
+	    kind: string;
+	    message: string;
+	    at: number;
+	    value: number;
+	    baseline: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Anomaly(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.message = source["message"];
+	        this.at = source["at"];
+	        this.value = source["value"];
+	        this.baseline = source["baseline"];
+	    }
+	}
+	export class ContextPoint {
+	    at: number;
+	    tokens: number;
+	    utilization: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContextPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.at = source["at"];
+	        this.tokens = source["tokens"];
+	        this.utilization = source["utilization"];
+	    }
+	}
+	export class ErrorEvent {
+	    sessionId: string;
+	    sessionName: string;
+	    kind: string;
+	    model?: string;
+	    message: string;
+	    at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ErrorEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.sessionName = source["sessionName"];
+	        this.kind = source["kind"];
+	        this.model = source["model"];
+	        this.message = source["message"];
+	        this.at = source["at"];
+	    }
+	}
+	export class Projection {
+	    actual: number;
+	    expected: number;
+	    low: number;
+	    high: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Projection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.actual = source["actual"];
+	        this.expected = source["expected"];
+	        this.low = source["low"];
+	        this.high = source["high"];
+	    }
+	}
+	export class Forecast {
+	    generatedAt: number;
+	    historyDays: number;
+	    dailyMean: number;
+	    dailyStdDev: number;
+	    endOfDay: Projection;
+	    endOfWeek: Projection;
+	    endOfMonth: Projection;
+	    byKind: Record<string, Projection>;
+	    byModel: Record<string, Projection>;
+	    dailyBudget?: number;
+	    monthlyBudget?: number;
+	    overDailyBudget?: boolean;
+	    overBudget?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Forecast(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generatedAt = source["generatedAt"];
+	        this.historyDays = source["historyDays"];
+	        this.dailyMean = source["dailyMean"];
+	        this.dailyStdDev = source["dailyStdDev"];
+	        this.endOfDay = this.convertValues(source["endOfDay"], Projection);
+	        this.endOfWeek = this.convertValues(source["endOfWeek"], Projection);
+	        this.endOfMonth = this.convertValues(source["endOfMonth"], Projection);
+	        this.byKind = this.convertValues(source["byKind"], Projection, true);
+	        this.byModel = this.convertValues(source["byModel"], Projection, true);
+	        this.dailyBudget = source["dailyBudget"];
+	        this.monthlyBudget = source["monthlyBudget"];
+	        this.overDailyBudget = source["overDailyBudget"];
+	        this.overBudget = source["overBudget"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Heatmap {
+	    from: number;
+	    to: number;
+	    messages: number[][];
+	    cost: number[][];
+	    maxMessages: number;
+	    maxCost: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Heatmap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.messages = source["messages"];
+	        this.cost = source["cost"];
+	        this.maxMessages = source["maxMessages"];
+	        this.maxCost = source["maxCost"];
+	    }
+	}
+	export class Percentiles {
+	    count: number;
+	    p50: number;
+	    p95: number;
+	    p99: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Percentiles(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.p50 = source["p50"];
+	        this.p95 = source["p95"];
+	        this.p99 = source["p99"];
+	    }
+	}
+	export class LatencyStats {
+	    key: string;
+	    name: string;
+	    turnLatency: Percentiles;
+	    toolTime: Percentiles;
+	    tokensPerSec: Percentiles;
+	
+	    static createFrom(source: any = {}) {
+	        return new LatencyStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.key = source["key"];
+	        this.name = source["name"];
+	        this.turnLatency = this.convertValues(source["turnLatency"], Percentiles);
+	        this.toolTime = this.convertValues(source["toolTime"], Percentiles);
+	        this.tokensPerSec = this.convertValues(source["tokensPerSec"], Percentiles);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LatencyReport {
+	    models: LatencyStats[];
+	    sessions: LatencyStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LatencyReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.models = this.convertValues(source["models"], LatencyStats);
+	        this.sessions = this.convertValues(source["sessions"], LatencyStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Session {
 	    sessionId: string;
 	    name: string;
 	    kind: string;
+	    agent: string;
 	    model: string;
 	    messageCount: number;
 	    totalCost: number;
 	    todayCost: number;
 	    updatedAt: number;
 	    isActive: boolean;
+	    parentId?: string;
+	    originalName?: string;
+	    tags?: string[];
+	    note?: string;
+	    pinned?: boolean;
+	    hidden?: boolean;
+	    anomalies?: Anomaly[];
+	    stuck?: boolean;
+	    stuckReason?: string;
+	    lastProgressAt?: number;
+	    errorCount: number;
+	    lastError?: ErrorEvent;
+	    latency?: LatencyStats;
+	    totalTokens: number;
+	    contextTokens: number;
+	    contextLimit: number;
+	    contextUtilization: number;
+	    contextWarning?: boolean;
+	    contextHistory?: ContextPoint[];
+	    compactionCount: number;
+	    lastCompactionAt?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Session(source);
@@ -20,19 +359,60 @@ export namespace main {
 	        this.sessionId = source["sessionId"];
 	        this.name = source["name"];
 	        this.kind = source["kind"];
+	        this.agent = source["agent"];
 	        this.model = source["model"];
 	        this.messageCount = source["messageCount"];
 	        this.totalCost = source["totalCost"];
 	        this.todayCost = source["todayCost"];
 	        this.updatedAt = source["updatedAt"];
 	        this.isActive = source["isActive"];
+	        this.parentId = source["parentId"];
+	        this.originalName = source["originalName"];
+	        this.tags = source["tags"];
+	        this.note = source["note"];
+	        this.pinned = source["pinned"];
+	        this.hidden = source["hidden"];
+	        this.anomalies = this.convertValues(source["anomalies"], Anomaly);
+	        this.stuck = source["stuck"];
+	        this.stuckReason = source["stuckReason"];
+	        this.lastProgressAt = source["lastProgressAt"];
+	        this.errorCount = source["errorCount"];
+	        this.lastError = this.convertValues(source["lastError"], ErrorEvent);
+	        this.latency = this.convertValues(source["latency"], LatencyStats);
+	        this.totalTokens = source["totalTokens"];
+	        this.contextTokens = source["contextTokens"];
+	        this.contextLimit = source["contextLimit"];
+	        this.contextUtilization = source["contextUtilization"];
+	        this.contextWarning = source["contextWarning"];
+	        this.contextHistory = this.convertValues(source["contextHistory"], ContextPoint);
+	        this.compactionCount = source["compactionCount"];
+	        this.lastCompactionAt = source["lastCompactionAt"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class DashboardData {
 	    sessions: Session[];
 	    totalCount: number;
 	    totalCost: number;
 	    todayCost: number;
+	    errorCount: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new DashboardData(source);
@@ -44,6 +424,101 @@ export namespace main {
 	        this.totalCount = source["totalCount"];
 	        this.totalCost = source["totalCost"];
 	        this.todayCost = source["todayCost"];
+	        this.errorCount = source["errorCount"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionChange {
+	    sessionId: string;
+	    new?: boolean;
+	    becameActive?: boolean;
+	    costDelta?: number;
+	    messageDelta?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.new = source["new"];
+	        this.becameActive = source["becameActive"];
+	        this.costDelta = source["costDelta"];
+	        this.messageDelta = source["messageDelta"];
+	    }
+	}
+	export class DashboardDiff {
+	    sessions: SessionChange[];
+	    sessionDelta: number;
+	    costDelta: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DashboardDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], SessionChange);
+	        this.sessionDelta = source["sessionDelta"];
+	        this.costDelta = source["costDelta"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DashboardUpdate {
+	    sessions: Session[];
+	    totalCount: number;
+	    totalCost: number;
+	    todayCost: number;
+	    errorCount: number;
+	    changes: DashboardDiff;
+	
+	    static createFrom(source: any = {}) {
+	        return new DashboardUpdate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], Session);
+	        this.totalCount = source["totalCount"];
+	        this.totalCost = source["totalCost"];
+	        this.todayCost = source["todayCost"];
+	        this.errorCount = source["errorCount"];
+	        this.changes = this.convertValues(source["changes"], DashboardDiff);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -65,5 +540,61 @@ export namespace main {
 		}
 	}
 
+	export class ToolMarker {
+	    at: number;
+	    name: string;
+	    failed?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ToolMarker(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.at = source["at"];
+	        this.name = source["name"];
+	        this.failed = source["failed"];
+	    }
+	}
+	export class SessionTimeline {
+	    sessionId: string;
+	    start: number;
+	    end: number;
+	    bucket: string;
+	    buckets: ActivityBucket[];
+	    toolCalls: ToolMarker[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionTimeline(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.bucket = source["bucket"];
+	        this.buckets = this.convertValues(source["buckets"], ActivityBucket);
+	        this.toolCalls = this.convertValues(source["toolCalls"], ToolMarker);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 }
 
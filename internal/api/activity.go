@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Supported activity bucket sizes.
+const (
+	BucketMinute     = time.Minute
+	BucketFiveMinute = 5 * time.Minute
+	BucketHour       = time.Hour
+	BucketDay        = 24 * time.Hour
+)
+
+// maxActivityBuckets caps how many buckets one GetActivity call returns.
+const maxActivityBuckets = 10000
+
+// ActivityFilter restricts which messages GetActivity counts. Empty fields
+// match everything.
+type ActivityFilter struct {
+	SessionID string `json:"sessionId,omitempty"`
+	Kind      string `json:"kind,omitempty"`  // main, cron or subagent
+	Agent     string `json:"agent,omitempty"` // agent directory name, e.g. "main"
+	Model     string `json:"model,omitempty"` // with or without provider prefix
+	Tag       string `json:"tag,omitempty"`   // user annotation tag
+}
+
+// ParseBucket converts a bucket name ("1m", "5m", "1h", "1d") to its size.
+func ParseBucket(s string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1m", "m", "minute":
+		return BucketMinute, nil
+	case "5m":
+		return BucketFiveMinute, nil
+	case "1h", "h", "hour", "":
+		return BucketHour, nil
+	case "1d", "d", "day", "24h":
+		return BucketDay, nil
+	}
+	return 0, fmt.Errorf("unsupported bucket %q (want 1m, 5m, 1h or 1d)", s)
+}
+
+// GetActivity returns message counts and costs between from and to in
+// buckets of the given size. Buckets are aligned to clock boundaries in
+// from's location, so the first one starts at or before from; days start
+// at local midnight.
+func (c *Client) GetActivity(from, to time.Time, bucket time.Duration, filter ActivityFilter) ([]ActivityBucket, error) {
+	switch bucket {
+	case BucketMinute, BucketFiveMinute, BucketHour, BucketDay:
+	default:
+		return nil, fmt.Errorf("unsupported bucket size %s", bucket)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("empty range: %s to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	var buckets []ActivityBucket
+	for t := alignBucket(from, bucket); t.Before(to); t = nextBucket(t, bucket) {
+		if len(buckets) == maxActivityBuckets {
+			return nil, fmt.Errorf("range too large for %s buckets", shortDuration(bucket))
+		}
+		buckets = append(buckets, ActivityBucket{Start: t})
+	}
+	start := buckets[0].Start
+
+	c.scanMessages(filter, func(m scannedMessage) {
+		if m.At.Before(start) || !m.At.Before(to) {
+			return
+		}
+		idx := sort.Search(len(buckets), func(i int) bool {
+			return buckets[i].Start.After(m.At)
+		}) - 1
+		buckets[idx].Messages++
+		buckets[idx].Cost += m.Cost
+	})
+	return buckets, nil
+}
+
+// scannedMessage is a timestamped transcript message found by scanMessages.
+type scannedMessage struct {
+	Agent     string
+	SessionID string
+	Kind      string
+	Model     string // the assistant model in effect at this message
+	At        time.Time
+	Cost      float64
+}
+
+// scanMessages calls visit for every timestamped message that matches
+// filter in the transcripts of the agents the client reads.
+func (c *Client) scanMessages(filter ActivityFilter, visit func(scannedMessage)) {
+	agents, err := c.agentNames()
+	if err != nil {
+		return
+	}
+	var annotations map[string]Annotation
+	if filter.Tag != "" {
+		annotations = c.GetAnnotations()
+	}
+	for _, agent := range agents {
+		if filter.Agent != "" && agent != filter.Agent {
+			continue
+		}
+		sessionsDir := c.sessionsDir(agent)
+		kinds := loadSessionKinds(sessionsDir)
+
+		files, err := os.ReadDir(sessionsDir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			if !strings.HasSuffix(f.Name(), ".jsonl") {
+				continue
+			}
+			sessionID := strings.TrimSuffix(f.Name(), ".jsonl")
+			if filter.SessionID != "" && sessionID != filter.SessionID {
+				continue
+			}
+			if filter.Tag != "" && !HasTag(annotations[sessionID].Tags, filter.Tag) {
+				continue
+			}
+			kind, ok := kinds[sessionID]
+			if !ok {
+				kind = "main"
+			}
+			if filter.Kind != "" && kind != filter.Kind {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(sessionsDir, f.Name()))
+			if err != nil {
+				continue
+			}
+
+			model := ""
+			for _, line := range strings.Split(string(data), "\n") {
+				if line == "" {
+					continue
+				}
+				var entry transcriptEntry
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					continue
+				}
+				if entry.Type != "message" || entry.Message == nil {
+					continue
+				}
+				if entry.Message.Role == "assistant" && entry.Message.Model != "" {
+					model = entry.Message.Model
+				}
+				if entry.Message.Timestamp <= 0 || !modelMatches(model, filter.Model) {
+					continue
+				}
+				m := scannedMessage{
+					Agent:     agent,
+					SessionID: sessionID,
+					Kind:      kind,
+					Model:     model,
+					At:        time.UnixMilli(entry.Message.Timestamp).In(c.location()),
+				}
+				if entry.Message.Usage != nil && entry.Message.Usage.Cost != nil {
+					m.Cost = entry.Message.Usage.Cost.Total
+				}
+				visit(m)
+			}
+		}
+	}
+}
+
+// loadSessionKinds maps session IDs to their kind using the sessions.json
+// index in dir.
+func loadSessionKinds(dir string) map[string]string {
+	kinds := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(dir, "sessions.json"))
+	if err != nil {
+		return kinds
+	}
+	var meta sessionsJSON
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return kinds
+	}
+	for key, entry := range meta {
+		kinds[entry.SessionID] = parseKind(key)
+	}
+	return kinds
+}
+
+// modelMatches reports whether model satisfies the filter want, which may
+// omit the provider prefix ("claude-opus-4-5" matches
+// "anthropic/claude-opus-4-5").
+func modelMatches(model, want string) bool {
+	if want == "" || model == want {
+		return true
+	}
+	return strings.HasSuffix(model, "/"+want)
+}
+
+// alignBucket returns the start of the bucket containing t.
+func alignBucket(t time.Time, bucket time.Duration) time.Time {
+	y, mo, d := t.Date()
+	if bucket == BucketDay {
+		return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+	}
+	step := int(bucket / time.Minute)
+	minute := t.Hour()*60 + t.Minute()
+	minute -= minute % step
+	return time.Date(y, mo, d, minute/60, minute%60, 0, 0, t.Location())
+}
+
+// nextBucket returns the start of the bucket after the one starting at t.
+// Days advance by calendar date so DST changes keep midnight alignment.
+func nextBucket(t time.Time, bucket time.Duration) time.Time {
+	if bucket == BucketDay {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(bucket)
+}
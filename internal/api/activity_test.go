@@ -0,0 +1,183 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testMessage is one assistant message in a fixture transcript.
+type testMessage struct {
+	at    time.Time
+	cost  float64
+	model string
+}
+
+// newTestClient returns a client over an empty OpenClaw tree in a temp
+// directory, with days in UTC.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	dir := t.TempDir()
+	c := NewClient(dir)
+	c.AnnotationsPath = filepath.Join(dir, "annotations.json")
+	c.Location = time.UTC
+	return c
+}
+
+// writeTranscript writes a session transcript for agent.
+func writeTranscript(t *testing.T, c *Client, agent, sessionID string, messages ...testMessage) {
+	t.Helper()
+	dir := c.sessionsDir(agent)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	for _, m := range messages {
+		model := m.model
+		if model == "" {
+			model = "anthropic/claude-sonnet-4-5"
+		}
+		fmt.Fprintf(&b, `{"type":"message","message":{"role":"assistant","model":%q,"timestamp":%d,"usage":{"cost":{"total":%g}}}}`+"\n",
+			model, m.at.UnixMilli(), m.cost)
+	}
+	if err := os.WriteFile(filepath.Join(dir, sessionID+".jsonl"), []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseBucket(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1m", BucketMinute, false},
+		{"5m", BucketFiveMinute, false},
+		{"", BucketHour, false},
+		{"1H", BucketHour, false},
+		{"day", BucketDay, false},
+		{"24h", BucketDay, false},
+		{"15m", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBucket(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseBucket(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAlignBucket(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+	at := time.Date(2026, 3, 10, 13, 47, 31, 500, berlin)
+	tests := []struct {
+		bucket time.Duration
+		want   time.Time
+	}{
+		{BucketMinute, time.Date(2026, 3, 10, 13, 47, 0, 0, berlin)},
+		{BucketFiveMinute, time.Date(2026, 3, 10, 13, 45, 0, 0, berlin)},
+		{BucketHour, time.Date(2026, 3, 10, 13, 0, 0, 0, berlin)},
+		{BucketDay, time.Date(2026, 3, 10, 0, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		if got := alignBucket(at, tt.bucket); !got.Equal(tt.want) || got.Location() != berlin {
+			t.Errorf("alignBucket(%s) = %v, want %v", tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestNextBucketAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// Clocks go forward on March 8, 2026, so that day is 23 hours long.
+	day := time.Date(2026, 3, 8, 0, 0, 0, 0, ny)
+	next := nextBucket(day, BucketDay)
+	if want := time.Date(2026, 3, 9, 0, 0, 0, 0, ny); !next.Equal(want) {
+		t.Errorf("nextBucket(day) = %v, want %v", next, want)
+	}
+	if got := nextBucket(day, BucketHour).Sub(day); got != time.Hour {
+		t.Errorf("nextBucket(hour) advanced %v", got)
+	}
+}
+
+func TestGetActivity(t *testing.T) {
+	c := newTestClient(t)
+	base := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	writeTranscript(t, c, "main", "session-a",
+		testMessage{at: base.Add(10 * time.Minute), cost: 0.5},
+		testMessage{at: base.Add(50 * time.Minute), cost: 0.25},
+		testMessage{at: base.Add(2*time.Hour + 5*time.Minute), cost: 1},
+		testMessage{at: base.Add(5 * time.Hour), cost: 9}, // after the range
+	)
+	writeTranscript(t, c, "ops", "session-b",
+		testMessage{at: base.Add(70 * time.Minute), cost: 2, model: "openai/gpt-5"},
+	)
+
+	from, to := base.Add(15*time.Minute), base.Add(3*time.Hour)
+	tests := []struct {
+		name     string
+		filter   ActivityFilter
+		messages []int
+		cost     []float64
+	}{
+		{"all agents", ActivityFilter{}, []int{2, 1, 1}, []float64{0.75, 2, 1}},
+		{"one agent", ActivityFilter{Agent: "ops"}, []int{0, 1, 0}, []float64{0, 2, 0}},
+		{"one session", ActivityFilter{SessionID: "session-a"}, []int{2, 0, 1}, []float64{0.75, 0, 1}},
+		{"model without provider", ActivityFilter{Model: "gpt-5"}, []int{0, 1, 0}, []float64{0, 2, 0}},
+	}
+	for _, tt := range tests {
+		buckets, err := c.GetActivity(from, to, BucketHour, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(buckets) != len(tt.messages) {
+			t.Fatalf("%s: %d buckets, want %d", tt.name, len(buckets), len(tt.messages))
+		}
+		// The first bucket starts at the hour, before from.
+		if !buckets[0].Start.Equal(base) {
+			t.Errorf("%s: first bucket starts %v, want %v", tt.name, buckets[0].Start, base)
+		}
+		for i, b := range buckets {
+			if b.Messages != tt.messages[i] || b.Cost != tt.cost[i] {
+				t.Errorf("%s: bucket %d = %d messages $%g, want %d $%g", tt.name, i, b.Messages, b.Cost, tt.messages[i], tt.cost[i])
+			}
+		}
+	}
+
+	c.Agents = []string{"main"}
+	buckets, err := c.GetActivity(from, to, BucketHour, ActivityFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buckets[1].Messages != 0 {
+		t.Errorf("Agents = [main] still counted the ops agent: %+v", buckets[1])
+	}
+}
+
+func TestGetActivityErrors(t *testing.T) {
+	c := newTestClient(t)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		from, to time.Time
+		bucket   time.Duration
+	}{
+		{"unsupported bucket", now, now.Add(time.Hour), 15 * time.Minute},
+		{"empty range", now, now, BucketHour},
+		{"reversed range", now, now.Add(-time.Hour), BucketHour},
+		{"too many buckets", now, now.Add(365 * 24 * time.Hour), BucketMinute},
+	}
+	for _, tt := range tests {
+		if _, err := c.GetActivity(tt.from, tt.to, tt.bucket, ActivityFilter{}); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Annotation is what the user has recorded about a session in Antenna:
+// a custom name, tags, a note, and whether it is pinned or hidden.
+type Annotation struct {
+	Name      string   `json:"name,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Note      string   `json:"note,omitempty"`
+	Pinned    bool     `json:"pinned,omitempty"`
+	Hidden    bool     `json:"hidden,omitempty"`
+	UpdatedAt int64    `json:"updatedAt,omitempty"`
+}
+
+func (a Annotation) isZero() bool {
+	return a.Name == "" && len(a.Tags) == 0 && a.Note == "" && !a.Pinned && !a.Hidden
+}
+
+type annotationsFile struct {
+	Sessions map[string]Annotation `json:"sessions"`
+}
+
+// DefaultAnnotationsPath is where annotations live unless the Client is
+// told otherwise: antenna/annotations.json under the user config directory
+// ($XDG_CONFIG_HOME or ~/.config on Linux). It returns "" if there is no
+// such directory.
+func DefaultAnnotationsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "antenna", "annotations.json")
+}
+
+// GetAnnotations returns all annotations keyed by session ID.
+func (c *Client) GetAnnotations() map[string]Annotation {
+	c.annotationsMu.Lock()
+	defer c.annotationsMu.Unlock()
+	return c.loadAnnotations()
+}
+
+// SetAnnotation replaces the annotation for sessionID. Tags are trimmed,
+// stripped of a leading '#' and deduplicated; an empty annotation removes
+// the entry.
+func (c *Client) SetAnnotation(sessionID string, a Annotation) error {
+	return c.UpdateAnnotation(sessionID, func(cur *Annotation) { *cur = a })
+}
+
+// UpdateAnnotation applies fn to the current annotation for sessionID and
+// saves the result.
+func (c *Client) UpdateAnnotation(sessionID string, fn func(*Annotation)) error {
+	if sessionID == "" {
+		return errors.New("annotation needs a session ID")
+	}
+	if c.AnnotationsPath == "" {
+		return errors.New("no annotations file configured")
+	}
+	c.annotationsMu.Lock()
+	defer c.annotationsMu.Unlock()
+
+	all := c.loadAnnotations()
+	a := all[sessionID]
+	fn(&a)
+	a.Name = strings.TrimSpace(a.Name)
+	a.Note = strings.TrimSpace(a.Note)
+	a.Tags = NormalizeTags(a.Tags)
+	if a.isZero() {
+		delete(all, sessionID)
+	} else {
+		a.UpdatedAt = time.Now().UnixMilli()
+		all[sessionID] = a
+	}
+	return c.saveAnnotations(all)
+}
+
+// NormalizeTags trims tags, drops a leading '#', removes empties and
+// duplicates, and sorts the rest.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var out []string
+	for _, t := range tags {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ParseTags splits a comma- or space-separated tag list.
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	}))
+}
+
+// HasTag reports whether tags contains tag, ignoring a leading '#'.
+func HasTag(tags []string, tag string) bool {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// loadAnnotations reads the annotations file. A missing or unreadable file
+// yields an empty map. Callers hold annotationsMu.
+func (c *Client) loadAnnotations() map[string]Annotation {
+	all := make(map[string]Annotation)
+	if c.AnnotationsPath == "" {
+		return all
+	}
+	data, err := os.ReadFile(c.AnnotationsPath)
+	if err != nil {
+		return all
+	}
+	var f annotationsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return all
+	}
+	for id, a := range f.Sessions {
+		all[id] = a
+	}
+	return all
+}
+
+// saveAnnotations writes the annotations file atomically. Callers hold
+// annotationsMu.
+func (c *Client) saveAnnotations(all map[string]Annotation) error {
+	data, err := json.MarshalIndent(annotationsFile{Sessions: all}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.AnnotationsPath), 0o755); err != nil {
+		return fmt.Errorf("creating annotations directory: %w", err)
+	}
+	tmp := c.AnnotationsPath + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing annotations: %w", err)
+	}
+	return os.Rename(tmp, c.AnnotationsPath)
+}
+
+// applyAnnotation merges a into s. The OpenClaw name is kept in
+// OriginalName when the user has renamed the session.
+func applyAnnotation(s *Session, a Annotation) {
+	if a.Name != "" && a.Name != s.Name {
+		s.OriginalName = s.Name
+		s.Name = a.Name
+	}
+	s.Tags = a.Tags
+	s.Note = a.Note
+	s.Pinned = a.Pinned
+	s.Hidden = a.Hidden
+}
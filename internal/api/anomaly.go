@@ -0,0 +1,259 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Anomaly kinds.
+const (
+	AnomalyRate  = "rate"  // message-rate spike
+	AnomalyLoop  = "loop"  // the same tool call repeated back to back
+	AnomalyCost  = "cost"  // hourly cost far above baseline
+	AnomalyModel = "model" // the assistant switched models mid-session
+)
+
+// AnomalyConfig tunes the anomaly detector.
+type AnomalyConfig struct {
+	// Lookback limits reported anomalies to those that happened recently.
+	Lookback time.Duration
+
+	// RateWindow is the bucket size for message-rate spikes. A window is
+	// flagged when it holds at least RateMin messages and RateFactor times
+	// the session's median non-empty window.
+	RateWindow time.Duration
+	RateFactor float64
+	RateMin    int
+
+	// LoopRepeat is how many identical consecutive tool calls make a loop.
+	LoopRepeat int
+
+	// An hour is flagged when its cost is at least CostMinPerHour and
+	// CostFactor times the baseline: the median of the session's other
+	// hours, or of all other runs of the same cron job.
+	CostFactor     float64
+	CostMinPerHour float64
+}
+
+// DefaultAnomalyConfig returns the detector settings NewClient uses.
+func DefaultAnomalyConfig() AnomalyConfig {
+	return AnomalyConfig{
+		Lookback:       24 * time.Hour,
+		RateWindow:     5 * time.Minute,
+		RateFactor:     4,
+		RateMin:        20,
+		LoopRepeat:     5,
+		CostFactor:     5,
+		CostMinPerHour: 1,
+	}
+}
+
+// detectAnomalies sets Anomalies on each session. At most one anomaly of
+// each kind is reported per session: the most recent one within Lookback.
+// cronJobs maps cron session IDs to their job ID so runs of the same job
+// share a cost baseline.
+func (c *Client) detectAnomalies(sessions []Session, streams map[string][]streamMessage, cronJobs map[string]string) {
+	cfg := c.Anomaly
+	since := time.Now().Add(-cfg.Lookback)
+
+	hourly := make(map[string]map[time.Time]float64, len(streams))
+	for id, stream := range streams {
+		hourly[id] = hourlyCost(stream)
+	}
+
+	for i := range sessions {
+		s := &sessions[i]
+		stream := streams[s.SessionID]
+		if len(stream) == 0 {
+			continue
+		}
+		var found []Anomaly
+		for _, a := range []*Anomaly{
+			detectRateSpike(stream, cfg),
+			detectToolLoop(stream, cfg),
+			detectCostSpike(s.SessionID, hourly, cronJobs, cfg),
+			detectModelChange(stream),
+		} {
+			if a != nil && !time.UnixMilli(a.At).Before(since) {
+				found = append(found, *a)
+			}
+		}
+		s.Anomalies = found
+	}
+}
+
+func detectRateSpike(stream []streamMessage, cfg AnomalyConfig) *Anomaly {
+	if cfg.RateWindow <= 0 {
+		return nil
+	}
+	counts := make(map[time.Time]int)
+	for _, m := range stream {
+		counts[m.Time.Truncate(cfg.RateWindow)]++
+	}
+	values := make([]float64, 0, len(counts))
+	for _, n := range counts {
+		values = append(values, float64(n))
+	}
+	baseline := median(values)
+
+	var hit *Anomaly
+	for start, n := range counts {
+		if n < cfg.RateMin || float64(n) < cfg.RateFactor*baseline {
+			continue
+		}
+		at := start.Add(cfg.RateWindow)
+		if hit != nil && at.UnixMilli() <= hit.At {
+			continue
+		}
+		hit = &Anomaly{
+			Kind:     AnomalyRate,
+			Message:  fmt.Sprintf("%d messages in %s (usually %.0f)", n, shortDuration(cfg.RateWindow), baseline),
+			At:       at.UnixMilli(),
+			Value:    float64(n),
+			Baseline: baseline,
+		}
+	}
+	return hit
+}
+
+func detectToolLoop(stream []streamMessage, cfg AnomalyConfig) *Anomaly {
+	if cfg.LoopRepeat < 2 {
+		return nil
+	}
+	var hit *Anomaly
+	lastKey := ""
+	run := 0
+	for _, m := range stream {
+		for _, call := range m.ToolCalls {
+			key := call.Name + "\x00" + string(call.Arguments)
+			if key == lastKey {
+				run++
+			} else {
+				lastKey, run = key, 1
+			}
+			if run >= cfg.LoopRepeat {
+				hit = &Anomaly{
+					Kind:    AnomalyLoop,
+					Message: fmt.Sprintf("%s called %d times in a row with the same arguments", call.Name, run),
+					At:      m.Time.UnixMilli(),
+					Value:   float64(run),
+				}
+			}
+		}
+	}
+	return hit
+}
+
+func detectCostSpike(sessionID string, hourly map[string]map[time.Time]float64, cronJobs map[string]string, cfg AnomalyConfig) *Anomaly {
+	own := hourly[sessionID]
+	if len(own) == 0 {
+		return nil
+	}
+
+	// Cron runs are short, so a job's baseline comes from its other runs.
+	var peers []float64
+	if job, ok := cronJobs[sessionID]; ok {
+		for id, other := range cronJobs {
+			if other != job || id == sessionID {
+				continue
+			}
+			for _, cost := range hourly[id] {
+				peers = append(peers, cost)
+			}
+		}
+	}
+
+	var hit *Anomaly
+	for hour, cost := range own {
+		if cost < cfg.CostMinPerHour {
+			continue
+		}
+		baseline := 0.0
+		if _, ok := cronJobs[sessionID]; ok {
+			if len(peers) < 2 {
+				continue
+			}
+			baseline = median(peers)
+		} else {
+			var others []float64
+			for h, c := range own {
+				if h != hour {
+					others = append(others, c)
+				}
+			}
+			if len(others) < 3 {
+				continue
+			}
+			baseline = median(others)
+		}
+		if baseline <= 0 || cost < cfg.CostFactor*baseline {
+			continue
+		}
+		at := hour.Add(time.Hour)
+		if hit != nil && at.UnixMilli() <= hit.At {
+			continue
+		}
+		hit = &Anomaly{
+			Kind:     AnomalyCost,
+			Message:  fmt.Sprintf("$%.2f in one hour (baseline $%.2f)", cost, baseline),
+			At:       at.UnixMilli(),
+			Value:    cost,
+			Baseline: baseline,
+		}
+	}
+	return hit
+}
+
+func detectModelChange(stream []streamMessage) *Anomaly {
+	var hit *Anomaly
+	prev := ""
+	for _, m := range stream {
+		if m.Role != "assistant" || m.Model == "" {
+			continue
+		}
+		if prev != "" && m.Model != prev {
+			hit = &Anomaly{
+				Kind:    AnomalyModel,
+				Message: fmt.Sprintf("model changed from %s to %s", prev, m.Model),
+				At:      m.Time.UnixMilli(),
+			}
+		}
+		prev = m.Model
+	}
+	return hit
+}
+
+func hourlyCost(stream []streamMessage) map[time.Time]float64 {
+	hours := make(map[time.Time]float64)
+	for _, m := range stream {
+		if m.Cost > 0 {
+			hours[m.Time.Truncate(time.Hour)] += m.Cost
+		}
+	}
+	return hours
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+var anomalyStart = time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+// messagesEvery returns n assistant messages step apart from start.
+func messagesEvery(start time.Time, n int, step time.Duration) []streamMessage {
+	stream := make([]streamMessage, n)
+	for i := range stream {
+		stream[i] = streamMessage{Time: start.Add(time.Duration(i) * step), Role: "assistant"}
+	}
+	return stream
+}
+
+func toolCall(at time.Time, name, args string) streamMessage {
+	return streamMessage{
+		Time:      at,
+		Role:      "assistant",
+		ToolCalls: []contentBlock{{Type: "toolCall", Name: name, Arguments: json.RawMessage(args)}},
+	}
+}
+
+func TestDetectRateSpike(t *testing.T) {
+	cfg := DefaultAnomalyConfig()
+	steady := messagesEvery(anomalyStart, 12, 5*time.Minute) // one per window
+	burst := messagesEvery(anomalyStart.Add(time.Hour), 25, time.Second)
+
+	tests := []struct {
+		name   string
+		stream []streamMessage
+		want   bool
+	}{
+		{"steady", steady, false},
+		{"burst", append(append([]streamMessage{}, steady...), burst...), true},
+		{"burst below minimum", append(append([]streamMessage{}, steady...), burst[:cfg.RateMin-1]...), false},
+	}
+	for _, tt := range tests {
+		a := detectRateSpike(tt.stream, cfg)
+		if (a != nil) != tt.want {
+			t.Errorf("%s: got %+v, want anomaly %v", tt.name, a, tt.want)
+			continue
+		}
+		if a != nil && (a.Kind != AnomalyRate || a.Value != 25 || a.Baseline != 1) {
+			t.Errorf("%s: got %+v", tt.name, a)
+		}
+	}
+}
+
+func TestDetectToolLoop(t *testing.T) {
+	cfg := DefaultAnomalyConfig()
+	repeat := func(n int, args ...string) []streamMessage {
+		var stream []streamMessage
+		for i := 0; i < n; i++ {
+			stream = append(stream, toolCall(anomalyStart.Add(time.Duration(i)*time.Second), "read", args[i%len(args)]))
+		}
+		return stream
+	}
+	tests := []struct {
+		name   string
+		stream []streamMessage
+		want   float64 // run length, 0 for none
+	}{
+		{"below repeat", repeat(cfg.LoopRepeat-1, `{"path":"a"}`), 0},
+		{"at repeat", repeat(cfg.LoopRepeat, `{"path":"a"}`), float64(cfg.LoopRepeat)},
+		{"longer run", repeat(cfg.LoopRepeat+2, `{"path":"a"}`), float64(cfg.LoopRepeat + 2)},
+		{"arguments differ", repeat(10, `{"path":"a"}`, `{"path":"b"}`), 0},
+	}
+	for _, tt := range tests {
+		a := detectToolLoop(tt.stream, cfg)
+		var got float64
+		if a != nil {
+			got = a.Value
+		}
+		if got != tt.want {
+			t.Errorf("%s: run %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectCostSpike(t *testing.T) {
+	cfg := DefaultAnomalyConfig()
+	hours := func(costs ...float64) map[time.Time]float64 {
+		m := make(map[time.Time]float64)
+		for i, c := range costs {
+			m[anomalyStart.Add(time.Duration(i)*time.Hour)] = c
+		}
+		return m
+	}
+	tests := []struct {
+		name     string
+		hourly   map[string]map[time.Time]float64
+		cronJobs map[string]string
+		want     float64 // flagged cost, 0 for none
+	}{
+		{"flat", map[string]map[time.Time]float64{"s": hours(0.2, 0.2, 0.2, 0.2)}, nil, 0},
+		{"spike", map[string]map[time.Time]float64{"s": hours(0.2, 0.2, 0.2, 2)}, nil, 2},
+		{"spike under minimum", map[string]map[time.Time]float64{"s": hours(0.1, 0.1, 0.1, 0.9)}, nil, 0},
+		{"too little history", map[string]map[time.Time]float64{"s": hours(0.2, 0.2, 2)}, nil, 0},
+		{
+			"cron against other runs",
+			map[string]map[time.Time]float64{"s": hours(3), "r1": hours(0.3), "r2": hours(0.4)},
+			map[string]string{"s": "job", "r1": "job", "r2": "job"},
+			3,
+		},
+		{
+			"cron with one other run",
+			map[string]map[time.Time]float64{"s": hours(3), "r1": hours(0.3)},
+			map[string]string{"s": "job", "r1": "job"},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		a := detectCostSpike("s", tt.hourly, tt.cronJobs, cfg)
+		var got float64
+		if a != nil {
+			got = a.Value
+		}
+		if got != tt.want {
+			t.Errorf("%s: flagged %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDetectModelChange(t *testing.T) {
+	msg := func(min int, role, model string) streamMessage {
+		return streamMessage{Time: anomalyStart.Add(time.Duration(min) * time.Minute), Role: role, Model: model}
+	}
+	tests := []struct {
+		name   string
+		stream []streamMessage
+		want   string
+	}{
+		{"one model", []streamMessage{msg(0, "assistant", "a"), msg(1, "assistant", "a")}, ""},
+		{"switch", []streamMessage{msg(0, "assistant", "a"), msg(1, "user", ""), msg(2, "assistant", "b")}, "model changed from a to b"},
+		{"last switch wins", []streamMessage{msg(0, "assistant", "a"), msg(1, "assistant", "b"), msg(2, "assistant", "c")}, "model changed from b to c"},
+		{"unset models skipped", []streamMessage{msg(0, "assistant", "a"), msg(1, "assistant", ""), msg(2, "assistant", "a")}, ""},
+	}
+	for _, tt := range tests {
+		a := detectModelChange(tt.stream)
+		got := ""
+		if a != nil {
+			got = a.Message
+		}
+		if got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		in   []float64
+		want float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 3},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+	for _, tt := range tests {
+		if got := median(tt.in); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
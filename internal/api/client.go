@@ -4,25 +4,121 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
 // Client reads OpenClaw session data from the local filesystem.
 type Client struct {
 	OpenclawDir string
+	Anomaly     AnomalyConfig
+
+	// Agents lists the agent directories under agents/ to read; empty
+	// means all of them. Sessions, errors, activity, the heatmap and
+	// forecasts all cover the same agents.
+	Agents []string
+
+	// ActiveWindow is how recently a session must have been updated to
+	// count as active.
+	ActiveWindow time.Duration
+
+	// Location is the time zone for day boundaries, activity buckets and
+	// forecasts. Nil means the local zone.
+	Location *time.Location
+
+	// StuckAfter is how long a sub-agent may wait on a tool call or an
+	// unanswered message before it is marked stuck.
+	StuckAfter time.Duration
+
+	// ContextLimits overrides or extends the built-in model context window
+	// sizes, keyed by model name without provider prefix.
+	ContextLimits map[string]int
+
+	// ContextWarn is the context utilization (0-1) above which a session
+	// gets ContextWarning set; 0 disables the warning.
+	ContextWarn float64
+
+	// DailyBudget and MonthlyBudget are the spend limits forecasts are
+	// compared against; 0 means none.
+	DailyBudget   float64
+	MonthlyBudget float64
+
+	// AnnotationsPath is the Antenna-owned file holding session names,
+	// tags, notes, pins and hidden flags.
+	AnnotationsPath string
+	annotationsMu   sync.Mutex
 }
 
 // NewClient creates a Client pointing at the given openclaw directory.
 // If dir is empty it defaults to ~/.openclaw.
 func NewClient(dir string) *Client {
 	if dir == "" {
-		dir = filepath.Join(os.Getenv("HOME"), ".openclaw")
+		dir = DefaultOpenclawDir()
 	}
-	return &Client{OpenclawDir: dir}
+	return &Client{
+		OpenclawDir:  dir,
+		Anomaly:      DefaultAnomalyConfig(),
+		ActiveWindow: 30 * time.Minute,
+		StuckAfter:   10 * time.Minute,
+		ContextWarn:  0.8,
+
+		AnnotationsPath: DefaultAnnotationsPath(),
+	}
+}
+
+// DefaultOpenclawDir returns ~/.openclaw.
+func DefaultOpenclawDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.Getenv("HOME")
+	}
+	return filepath.Join(home, ".openclaw")
+}
+
+// Now returns the current time in the client's Location.
+func (c *Client) Now() time.Time {
+	return time.Now().In(c.location())
+}
+
+// In returns t in the client's Location, for display.
+func (c *Client) In(t time.Time) time.Time {
+	return t.In(c.location())
+}
+
+func (c *Client) location() *time.Location {
+	if c.Location != nil {
+		return c.Location
+	}
+	return time.Local
+}
+
+// agentNames returns the agents to read: Agents if set, otherwise every
+// directory under agents/.
+func (c *Client) agentNames() ([]string, error) {
+	if len(c.Agents) > 0 {
+		return c.Agents, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(c.OpenclawDir, "agents"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func (c *Client) sessionsDir(agent string) string {
+	return filepath.Join(c.OpenclawDir, "agents", agent, "sessions")
 }
 
 // --- internal JSON shapes ---
@@ -30,11 +126,13 @@ func NewClient(dir string) *Client {
 type sessionsJSON map[string]sessionEntry
 
 type sessionEntry struct {
-	SessionID   string `json:"sessionId"`
-	UpdatedAt   int64  `json:"updatedAt"`
-	Label       string `json:"label,omitempty"`
-	Model       string `json:"model,omitempty"`
-	TotalTokens int    `json:"totalTokens"`
+	SessionID     string `json:"sessionId"`
+	UpdatedAt     int64  `json:"updatedAt"`
+	Label         string `json:"label,omitempty"`
+	Model         string `json:"model,omitempty"`
+	TotalTokens   int    `json:"totalTokens"`
+	ContextTokens int    `json:"contextTokens,omitempty"`
+	SpawnedBy     string `json:"spawnedBy,omitempty"`
 }
 
 type cronJobsFile struct {
@@ -47,17 +145,98 @@ type cronJob struct {
 }
 
 type transcriptEntry struct {
-	Type    string          `json:"type"`
-	Message *messageContent `json:"message,omitempty"`
+	Type      string          `json:"type"`
+	Timestamp string          `json:"timestamp,omitempty"`
+	Message   *messageContent `json:"message,omitempty"`
+
+	// Set on "error" entries.
+	Error string `json:"error,omitempty"`
+
+	// Set on "compaction" entries.
+	TokensBefore int `json:"tokensBefore,omitempty"`
 }
 
 type messageContent struct {
-	Timestamp int64      `json:"timestamp,omitempty"`
-	Usage     *usageInfo `json:"usage,omitempty"`
+	Role         string        `json:"role,omitempty"`
+	Model        string        `json:"model,omitempty"`
+	Content      contentBlocks `json:"content,omitempty"`
+	Timestamp    int64         `json:"timestamp,omitempty"`
+	Usage        *usageInfo    `json:"usage,omitempty"`
+	StopReason   string        `json:"stopReason,omitempty"`
+	ErrorMessage string        `json:"errorMessage,omitempty"`
+	ToolName     string        `json:"toolName,omitempty"`
+	ToolCallID   string        `json:"toolCallId,omitempty"`
+	IsError      bool          `json:"isError,omitempty"`
+}
+
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// contentBlocks accepts both the block array OpenClaw writes and the plain
+// string some older transcripts use. Anything else is ignored rather than
+// failing the whole entry.
+type contentBlocks []contentBlock
+
+func (c *contentBlocks) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		json.Unmarshal(data, &text)
+		*c = contentBlocks{{Type: "text", Text: text}}
+		return nil
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(data, &blocks); err == nil {
+		*c = blocks
+	}
+	return nil
+}
+
+// text returns the message's text blocks joined by newlines.
+func (m *messageContent) text() string {
+	var parts []string
+	for _, b := range m.Content {
+		if b.Type == "text" && b.Text != "" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toolCalls returns the tool call blocks of an assistant message.
+func (m *messageContent) toolCalls() []contentBlock {
+	var calls []contentBlock
+	for _, b := range m.Content {
+		if b.Type == "toolCall" {
+			calls = append(calls, b)
+		}
+	}
+	return calls
+}
+
+// streamMessage is one timestamped transcript message, as used by the
+// detectors and latency metrics.
+type streamMessage struct {
+	Time         time.Time
+	Role         string
+	Model        string
+	Cost         float64
+	OutputTokens int
+	ToolCalls    []contentBlock
+	ToolCallID   string // set on tool results
 }
 
 type usageInfo struct {
-	Cost *costInfo `json:"cost,omitempty"`
+	Input       int       `json:"input,omitempty"`
+	Output      int       `json:"output,omitempty"`
+	CacheRead   int       `json:"cacheRead,omitempty"`
+	CacheWrite  int       `json:"cacheWrite,omitempty"`
+	TotalTokens int       `json:"totalTokens,omitempty"`
+	Cost        *costInfo `json:"cost,omitempty"`
 }
 
 type costInfo struct {
@@ -66,72 +245,29 @@ type costInfo struct {
 
 // GetDashboard returns aggregated dashboard data.
 func (c *Client) GetDashboard() DashboardData {
-	sessions := c.loadSessions()
+	data, _ := c.LoadDashboard()
+	return data
+}
+
+// LoadDashboard is GetDashboard that also reports why sessions could not
+// be read. The data is still usable when only a sessions.json index is
+// broken or a configured agent is missing.
+func (c *Client) LoadDashboard() (DashboardData, error) {
+	sessions, err := c.loadSessions()
 	var totalCost, todayCost float64
+	var errorCount int
 	for _, s := range sessions {
 		totalCost += s.TotalCost
 		todayCost += s.TodayCost
+		errorCount += s.ErrorCount
 	}
 	return DashboardData{
 		Sessions:   sessions,
 		TotalCount: len(sessions),
 		TotalCost:  totalCost,
 		TodayCost:  todayCost,
-	}
-}
-
-// GetHourlyActivity returns 24 buckets of message counts and costs.
-func (c *Client) GetHourlyActivity() []HourlyBucket {
-	now := time.Now()
-	cutoff := now.Add(-24 * time.Hour)
-
-	buckets := make([]HourlyBucket, 24)
-	for i := 0; i < 24; i++ {
-		t := cutoff.Add(time.Duration(i+1) * time.Hour)
-		buckets[i] = HourlyBucket{Hour: t.Format("15:00")}
-	}
-
-	sessionsDir := filepath.Join(c.OpenclawDir, "agents", "main", "sessions")
-	files, err := os.ReadDir(sessionsDir)
-	if err != nil {
-		return buckets
-	}
-
-	for _, f := range files {
-		if !strings.HasSuffix(f.Name(), ".jsonl") {
-			continue
-		}
-		data, err := os.ReadFile(filepath.Join(sessionsDir, f.Name()))
-		if err != nil {
-			continue
-		}
-		for _, line := range strings.Split(string(data), "\n") {
-			if line == "" {
-				continue
-			}
-			var entry transcriptEntry
-			if err := json.Unmarshal([]byte(line), &entry); err != nil {
-				continue
-			}
-			if entry.Type != "message" || entry.Message == nil || entry.Message.Timestamp <= 0 {
-				continue
-			}
-			msgTime := time.UnixMilli(entry.Message.Timestamp)
-			if msgTime.Before(cutoff) || msgTime.After(now) {
-				continue
-			}
-			idx := int(msgTime.Sub(cutoff).Hours())
-			if idx >= 24 {
-				idx = 23
-			}
-			buckets[idx].Messages++
-			if entry.Message.Usage != nil && entry.Message.Usage.Cost != nil {
-				buckets[idx].Cost += entry.Message.Usage.Cost.Total
-			}
-		}
-	}
-
-	return buckets
+		ErrorCount: errorCount,
+	}, err
 }
 
 func (c *Client) loadCronJobNames() map[string]string {
@@ -150,14 +286,54 @@ func (c *Client) loadCronJobNames() map[string]string {
 	return names
 }
 
-func (c *Client) loadSessions() []Session {
+func (c *Client) loadSessions() ([]Session, error) {
 	var sessions []Session
 	cronNames := c.loadCronJobNames()
+	annotations := c.GetAnnotations()
+
+	agents, err := c.agentNames()
+	if err != nil {
+		return nil, fmt.Errorf("reading sessions: %w", err)
+	}
+
+	// Index keys name their agent ("agent:ops:cron:…"), so the indexes of
+	// all agents share one map.
+	type agentDir struct {
+		agent string
+		files []os.DirEntry
+	}
+	var dirs []agentDir
+	var errs []error
+	sessionMeta := make(sessionsJSON)
+	for _, agent := range agents {
+		dir := c.sessionsDir(agent)
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			// An agent directory without sessions is only worth
+			// reporting when it was asked for.
+			if len(c.Agents) > 0 || !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		dirs = append(dirs, agentDir{agent, files})
 
-	sessionsFile := filepath.Join(c.OpenclawDir, "agents", "main", "sessions", "sessions.json")
-	var sessionMeta sessionsJSON
-	if data, err := os.ReadFile(sessionsFile); err == nil {
-		json.Unmarshal(data, &sessionMeta)
+		sessionsFile := filepath.Join(dir, "sessions.json")
+		if data, err := os.ReadFile(sessionsFile); err == nil {
+			var meta sessionsJSON
+			if err := json.Unmarshal(data, &meta); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", sessionsFile, err))
+			}
+			for key, entry := range meta {
+				sessionMeta[key] = entry
+			}
+		}
+	}
+	if len(dirs) == 0 {
+		if len(errs) == 0 {
+			errs = append(errs, fmt.Errorf("no agent sessions in %s", filepath.Join(c.OpenclawDir, "agents")))
+		}
+		return nil, fmt.Errorf("reading sessions: %w", errors.Join(errs...))
 	}
 
 	metaByID := make(map[string]struct {
@@ -171,71 +347,92 @@ func (c *Client) loadSessions() []Session {
 		}{key, entry}
 	}
 
-	sessionsDir := filepath.Join(c.OpenclawDir, "agents", "main", "sessions")
-	files, err := os.ReadDir(sessionsDir)
-	if err != nil {
-		return sessions
-	}
-
-	today := time.Now().Truncate(24 * time.Hour)
+	today := alignBucket(c.Now(), BucketDay)
 	seen := make(map[string]bool)
+	streams := make(map[string][]streamMessage)
+	cronJobs := make(map[string]string)
 
-	for _, f := range files {
-		if !strings.HasSuffix(f.Name(), ".jsonl") {
-			continue
-		}
-		sessionID := strings.TrimSuffix(f.Name(), ".jsonl")
-		if seen[sessionID] {
-			continue
-		}
-		seen[sessionID] = true
-
-		info, _ := f.Info()
-		s := Session{
-			SessionID: sessionID,
-			Kind:      "main",
-			UpdatedAt: info.ModTime().UnixMilli(),
-			IsActive:  time.Since(info.ModTime()) < 30*time.Minute,
-		}
-
-		if meta, ok := metaByID[sessionID]; ok {
-			s.Name = meta.Entry.Label
-			s.Model = meta.Entry.Model
-			s.Kind = parseKind(meta.Key)
-			if meta.Entry.UpdatedAt > 0 {
-				s.UpdatedAt = meta.Entry.UpdatedAt
-				s.IsActive = time.Since(time.UnixMilli(meta.Entry.UpdatedAt)) < 30*time.Minute
+	for _, d := range dirs {
+		for _, f := range d.files {
+			if !strings.HasSuffix(f.Name(), ".jsonl") {
+				continue
 			}
-			if s.Kind == "cron" && s.Name == "" {
-				parts := strings.Split(meta.Key, ":")
-				if len(parts) >= 4 {
-					if name, ok := cronNames[parts[3]]; ok {
-						s.Name = name
+			sessionID := strings.TrimSuffix(f.Name(), ".jsonl")
+			if seen[sessionID] {
+				continue
+			}
+			seen[sessionID] = true
+			info, _ := f.Info()
+			s := Session{
+				SessionID: sessionID,
+				Kind:      "main",
+				Agent:     d.agent,
+				UpdatedAt: info.ModTime().UnixMilli(),
+				IsActive:  time.Since(info.ModTime()) < c.ActiveWindow,
+			}
+
+			if meta, ok := metaByID[sessionID]; ok {
+				if parent, ok := sessionMeta[meta.Entry.SpawnedBy]; ok {
+					s.ParentID = parent.SessionID
+				}
+				s.Name = meta.Entry.Label
+				s.Model = meta.Entry.Model
+				s.TotalTokens = meta.Entry.TotalTokens
+				s.ContextLimit = meta.Entry.ContextTokens
+				s.Kind = parseKind(meta.Key)
+				if parts := strings.Split(meta.Key, ":"); len(parts) >= 2 && parts[0] == "agent" {
+					s.Agent = parts[1]
+				}
+				if meta.Entry.UpdatedAt > 0 {
+					s.UpdatedAt = meta.Entry.UpdatedAt
+					s.IsActive = time.Since(time.UnixMilli(meta.Entry.UpdatedAt)) < c.ActiveWindow
+				}
+				if s.Kind == "cron" {
+					parts := strings.Split(meta.Key, ":")
+					if len(parts) >= 4 {
+						cronJobs[sessionID] = parts[3]
+						if name, ok := cronNames[parts[3]]; ok && s.Name == "" {
+							s.Name = name
+						}
 					}
 				}
 			}
-		}
 
-		if s.Name == "" {
-			switch s.Kind {
-			case "main":
-				s.Name = time.UnixMilli(s.UpdatedAt).Format("Jan 2 15:04")
-			case "cron":
-				s.Name = "cron-" + sessionID[:8]
-			default:
-				s.Name = sessionID[:12]
+			if s.Name == "" {
+				switch s.Kind {
+				case "main":
+					s.Name = c.In(time.UnixMilli(s.UpdatedAt)).Format("Jan 2 15:04")
+				case "cron":
+					s.Name = "cron-" + sessionID[:8]
+				default:
+					s.Name = sessionID[:12]
+				}
 			}
-		}
 
-		c.parseSessionCost(sessionID, &s, today)
-		sessions = append(sessions, s)
+			if a, ok := annotations[sessionID]; ok {
+				applyAnnotation(&s, a)
+			}
+
+			stream := c.parseTranscript(filepath.Join(c.sessionsDir(d.agent), f.Name()), &s, today)
+			c.applyContext(&s)
+			s.latency = collectLatency(stream, s.Model)
+			s.Latency = s.latency.merged().stats(sessionID, s.Name)
+			streams[sessionID] = stream
+			sessions = append(sessions, s)
+		}
 	}
 
+	c.detectAnomalies(sessions, streams, cronJobs)
+	c.detectStuck(sessions, streams)
+
 	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].Pinned != sessions[j].Pinned {
+			return sessions[i].Pinned
+		}
 		return sessions[i].UpdatedAt > sessions[j].UpdatedAt
 	})
 
-	return sessions
+	return sessions, errors.Join(errs...)
 }
 
 func parseKind(key string) string {
@@ -251,12 +448,15 @@ func parseKind(key string) string {
 	return "main"
 }
 
-func (c *Client) parseSessionCost(sessionID string, s *Session, today time.Time) {
-	path := filepath.Join(c.OpenclawDir, "agents", "main", "sessions", sessionID+".jsonl")
+// parseTranscript fills in message counts, costs and errors for s and
+// returns its timestamped message stream for the detectors.
+func (c *Client) parseTranscript(path string, s *Session, today time.Time) []streamMessage {
+	var stream []streamMessage
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return
+		return stream
 	}
+	model := s.Model
 	for _, line := range strings.Split(string(data), "\n") {
 		if line == "" {
 			continue
@@ -265,18 +465,59 @@ func (c *Client) parseSessionCost(sessionID string, s *Session, today time.Time)
 		if err := json.Unmarshal([]byte(line), &entry); err != nil {
 			continue
 		}
+		if entry.Message != nil && entry.Message.Role == "assistant" && entry.Message.Model != "" {
+			model = entry.Message.Model
+		}
+		if isCompaction(entry.Type) {
+			s.CompactionCount++
+			if at := entryTime(&entry); at > 0 {
+				s.LastCompactionAt = at
+			}
+		}
+		if tokens := entry.contextTokens(); tokens > 0 {
+			s.ContextHistory = append(s.ContextHistory, ContextPoint{At: entryTime(&entry), Tokens: tokens})
+		}
+		if ev, ok := errorFromEntry(&entry, model); ok {
+			ev.SessionID = s.SessionID
+			ev.SessionName = s.Name
+			s.Errors = append(s.Errors, ev)
+		}
 		if entry.Type == "message" && entry.Message != nil {
 			s.MessageCount++
+			var cost float64
 			if entry.Message.Usage != nil && entry.Message.Usage.Cost != nil {
-				cost := entry.Message.Usage.Cost.Total
+				cost = entry.Message.Usage.Cost.Total
 				s.TotalCost += cost
 				if entry.Message.Timestamp > 0 {
 					msgTime := time.UnixMilli(entry.Message.Timestamp)
-					if msgTime.After(today) {
+					if !msgTime.Before(today) {
 						s.TodayCost += cost
 					}
 				}
 			}
+			if entry.Message.Timestamp > 0 {
+				sm := streamMessage{
+					Time:       time.UnixMilli(entry.Message.Timestamp),
+					Role:       entry.Message.Role,
+					Model:      entry.Message.Model,
+					Cost:       cost,
+					ToolCalls:  entry.Message.toolCalls(),
+					ToolCallID: entry.Message.ToolCallID,
+				}
+				if entry.Message.Usage != nil {
+					sm.OutputTokens = entry.Message.Usage.Output
+				}
+				stream = append(stream, sm)
+			}
 		}
 	}
+	if s.Model == "" {
+		s.Model = model
+	}
+	if n := len(s.Errors); n > 0 {
+		last := s.Errors[n-1]
+		s.ErrorCount = n
+		s.LastError = &last
+	}
+	return stream
 }
@@ -0,0 +1,103 @@
+package api
+
+import "strings"
+
+// contextLimits are context window sizes in tokens, keyed by model name
+// prefix. The longest matching prefix wins, so dated or suffixed model IDs
+// resolve to their family.
+var contextLimits = map[string]int{
+	"claude-opus-4":     200000,
+	"claude-sonnet-4":   200000,
+	"claude-haiku-4":    200000,
+	"claude-3-7-sonnet": 200000,
+	"claude-3-5-sonnet": 200000,
+	"claude-3-5-haiku":  200000,
+	"claude-3-opus":     200000,
+	"gpt-5":             400000,
+	"gpt-4.1":           1047576,
+	"gpt-4o":            128000,
+	"o3":                200000,
+	"o4-mini":           200000,
+	"gemini-2.5-pro":    1048576,
+	"gemini-2.5-flash":  1048576,
+}
+
+// maxContextHistory caps how many points a session's context history keeps.
+const maxContextHistory = 120
+
+// ContextLimit returns the context window size for model, or 0 if it is
+// unknown. Client.ContextLimits takes precedence over the built-in table.
+func (c *Client) ContextLimit(model string) int {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	best, limit := -1, 0
+	for _, table := range []map[string]int{contextLimits, c.ContextLimits} {
+		for prefix, n := range table {
+			// >= so that an override of the same prefix replaces the built-in.
+			if strings.HasPrefix(model, prefix) && len(prefix) >= best {
+				best, limit = len(prefix), n
+			}
+		}
+	}
+	return limit
+}
+
+// isCompaction reports whether a transcript entry type marks the context
+// being compacted or summarised.
+func isCompaction(entryType string) bool {
+	switch entryType {
+	case "compaction", "summary", "branch_summary":
+		return true
+	}
+	return false
+}
+
+// contextTokens returns the prompt plus reply size of an assistant turn,
+// which is what occupies the context window afterwards.
+func (e *transcriptEntry) contextTokens() int {
+	if e.Type != "message" || e.Message == nil || e.Message.Role != "assistant" || e.Message.Usage == nil {
+		return 0
+	}
+	u := e.Message.Usage
+	if u.TotalTokens > 0 {
+		return u.TotalTokens
+	}
+	return u.Input + u.Output + u.CacheRead + u.CacheWrite
+}
+
+// applyContext resolves the context limit for s and fills in utilization,
+// the warning flag and a downsampled history.
+func (c *Client) applyContext(s *Session) {
+	if n := len(s.ContextHistory); n > 0 {
+		s.ContextTokens = s.ContextHistory[n-1].Tokens
+	} else {
+		s.ContextTokens = s.TotalTokens
+	}
+	s.ContextHistory = downsample(s.ContextHistory, maxContextHistory)
+	if s.ContextLimit == 0 {
+		s.ContextLimit = c.ContextLimit(s.Model)
+	}
+	if s.ContextLimit == 0 {
+		return
+	}
+
+	s.ContextUtilization = float64(s.ContextTokens) / float64(s.ContextLimit)
+	s.ContextWarning = c.ContextWarn > 0 && s.ContextUtilization >= c.ContextWarn
+	for i := range s.ContextHistory {
+		s.ContextHistory[i].Utilization = float64(s.ContextHistory[i].Tokens) / float64(s.ContextLimit)
+	}
+}
+
+// downsample keeps at most max points, always including the last one.
+func downsample(points []ContextPoint, max int) []ContextPoint {
+	if len(points) <= max {
+		return points
+	}
+	out := make([]ContextPoint, 0, max)
+	step := float64(len(points)-1) / float64(max-1)
+	for i := 0; i < max; i++ {
+		out = append(out, points[int(float64(i)*step+0.5)])
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package api
+
+// SessionChange is how one session changed between two dashboard loads.
+type SessionChange struct {
+	SessionID    string  `json:"sessionId"`
+	New          bool    `json:"new,omitempty"`          // not in the earlier load
+	BecameActive bool    `json:"becameActive,omitempty"` // idle before, active now
+	CostDelta    float64 `json:"costDelta,omitempty"`    // total cost added
+	MessageDelta int     `json:"messageDelta,omitempty"` // messages added
+}
+
+// Merge adds a later change to c, so deltas accumulate over several loads.
+func (c SessionChange) Merge(later SessionChange) SessionChange {
+	c.New = c.New || later.New
+	c.BecameActive = c.BecameActive || later.BecameActive
+	c.CostDelta += later.CostDelta
+	c.MessageDelta += later.MessageDelta
+	return c
+}
+
+// DashboardDiff is how a dashboard changed since the previous load.
+type DashboardDiff struct {
+	Sessions     []SessionChange `json:"sessions"`
+	SessionDelta int             `json:"sessionDelta"` // sessions gained; negative if lost
+	CostDelta    float64         `json:"costDelta"`    // total cost gained, once it reaches MinCostDelta
+}
+
+// MinCostDelta is the smallest cost increase reported: half a cent, the
+// least that shows as "+$0.01".
+const MinCostDelta = 0.005
+
+// CostAccumulator adds up cost increases until they reach MinCostDelta, so
+// a session spending a little between every pair of loads still shows a
+// change once the spend adds up.
+type CostAccumulator float64
+
+// Add records the cost gained since the last call and returns what to
+// report: everything gained since the last report once it reaches
+// MinCostDelta, otherwise 0. A net decrease, e.g. from a rewritten
+// transcript, starts over.
+func (a *CostAccumulator) Add(delta float64) float64 {
+	sum := float64(*a) + delta
+	switch {
+	case sum >= MinCostDelta:
+		*a = 0
+		return sum
+	case sum < 0:
+		*a = 0
+	default:
+		*a = CostAccumulator(sum)
+	}
+	return 0
+}
+
+// ChangeTracker diffs each dashboard load against the one before it. The
+// zero value is ready to use; the first load reports no changes.
+type ChangeTracker struct {
+	loaded bool
+	prev   map[string]Session
+	count  int
+	cost   float64
+	carry  map[string]CostAccumulator // unreported cost gained, by session ID
+	total  CostAccumulator
+}
+
+// Track returns the sessions in next that are new since the previous load,
+// became active, or gained cost or messages, along with how the totals
+// moved. Sessions that lost cost or messages are not reported.
+func (t *ChangeTracker) Track(next DashboardData) DashboardDiff {
+	var diff DashboardDiff
+	carry := make(map[string]CostAccumulator)
+	if t.loaded {
+		diff.SessionDelta = next.TotalCount - t.count
+		diff.CostDelta = t.total.Add(next.TotalCost - t.cost)
+		for _, s := range next.Sessions {
+			old, ok := t.prev[s.SessionID]
+			if !ok {
+				diff.Sessions = append(diff.Sessions, SessionChange{SessionID: s.SessionID, New: true})
+				continue
+			}
+			c := SessionChange{SessionID: s.SessionID, BecameActive: s.IsActive && !old.IsActive}
+			acc := t.carry[s.SessionID]
+			c.CostDelta = acc.Add(s.TotalCost - old.TotalCost)
+			if acc != 0 {
+				carry[s.SessionID] = acc
+			}
+			if d := s.MessageCount - old.MessageCount; d > 0 {
+				c.MessageDelta = d
+			}
+			if c.BecameActive || c.CostDelta > 0 || c.MessageDelta > 0 {
+				diff.Sessions = append(diff.Sessions, c)
+			}
+		}
+	}
+
+	t.loaded = true
+	t.prev = make(map[string]Session, len(next.Sessions))
+	for _, s := range next.Sessions {
+		t.prev[s.SessionID] = s
+	}
+	t.count, t.cost, t.carry = next.TotalCount, next.TotalCost, carry
+	return diff
+}
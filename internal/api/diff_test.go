@@ -0,0 +1,117 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangeTracker(t *testing.T) {
+	first := DashboardData{TotalCount: 6, TotalCost: 15, Sessions: []Session{
+		{SessionID: "idle", TotalCost: 1, MessageCount: 10},
+		{SessionID: "busy", TotalCost: 2, MessageCount: 20, IsActive: true},
+		{SessionID: "waking", TotalCost: 3, MessageCount: 30},
+		{SessionID: "dust", TotalCost: 4, MessageCount: 40},
+		{SessionID: "rewritten", TotalCost: 5, MessageCount: 50},
+		{SessionID: "gone"},
+	}}
+	second := DashboardData{TotalCount: 6, TotalCost: 11.604, Sessions: []Session{
+		{SessionID: "idle", TotalCost: 1, MessageCount: 10},
+		{SessionID: "busy", TotalCost: 2.5, MessageCount: 23, IsActive: true},
+		{SessionID: "waking", TotalCost: 3, MessageCount: 30, IsActive: true},
+		{SessionID: "dust", TotalCost: 4.004, MessageCount: 40},
+		{SessionID: "rewritten", TotalCost: 1, MessageCount: 5},
+		{SessionID: "fresh", TotalCost: 0.1, MessageCount: 1},
+	}}
+	third := DashboardData{TotalCount: 6, TotalCost: 11.608, Sessions: []Session{
+		{SessionID: "idle", TotalCost: 1, MessageCount: 10},
+		{SessionID: "busy", TotalCost: 2.5, MessageCount: 23, IsActive: true},
+		{SessionID: "waking", TotalCost: 3, MessageCount: 30, IsActive: true},
+		{SessionID: "dust", TotalCost: 4.008, MessageCount: 40},
+		{SessionID: "rewritten", TotalCost: 1, MessageCount: 5},
+		{SessionID: "fresh", TotalCost: 0.1, MessageCount: 1},
+	}}
+
+	var tr ChangeTracker
+	if diff := tr.Track(first); !reflect.DeepEqual(diff, DashboardDiff{}) {
+		t.Errorf("first load: %+v, want no changes", diff)
+	}
+
+	diff := tr.Track(second)
+	want := []SessionChange{
+		{SessionID: "busy", CostDelta: 0.5, MessageDelta: 3},
+		{SessionID: "waking", BecameActive: true},
+		{SessionID: "fresh", New: true},
+	}
+	if !reflect.DeepEqual(diff.Sessions, want) {
+		t.Errorf("second load: %+v\nwant %+v", diff.Sessions, want)
+	}
+	if diff.SessionDelta != 0 || diff.CostDelta != 0 {
+		t.Errorf("second load totals: %+v", diff)
+	}
+
+	// dust's two increases of $0.004 add up to one worth reporting.
+	diff = tr.Track(third)
+	if len(diff.Sessions) != 1 || diff.Sessions[0].SessionID != "dust" || !near(diff.Sessions[0].CostDelta, 0.008) {
+		t.Errorf("third load: %+v, want dust +$0.008", diff.Sessions)
+	}
+	if diff.CostDelta != 0 {
+		t.Errorf("third load total: %v, want the $0.004 held back", diff.CostDelta)
+	}
+
+	if diff := tr.Track(third); len(diff.Sessions) != 0 {
+		t.Errorf("unchanged load: %+v", diff.Sessions)
+	}
+}
+
+func TestCostAccumulator(t *testing.T) {
+	tests := []struct {
+		name   string
+		deltas []float64
+		want   []float64 // reported after each delta
+	}{
+		{"large", []float64{0.5, 0.01}, []float64{0.5, 0.01}},
+		{"small ones add up", []float64{0.002, 0.002, 0.002, 0.002}, []float64{0, 0, 0.006, 0}},
+		{"decrease starts over", []float64{0.004, -0.01, 0.004, 0.004}, []float64{0, 0, 0, 0.008}},
+		{"no change", []float64{0, 0}, []float64{0, 0}},
+	}
+	for _, tt := range tests {
+		var a CostAccumulator
+		for i, d := range tt.deltas {
+			if got := a.Add(d); !near(got, tt.want[i]) {
+				t.Errorf("%s: Add #%d = %v, want %v", tt.name, i, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSessionChangeMerge(t *testing.T) {
+	tests := []struct {
+		name         string
+		first, later SessionChange
+		want         SessionChange
+	}{
+		{
+			"deltas add up",
+			SessionChange{SessionID: "s", CostDelta: 0.25, MessageDelta: 2},
+			SessionChange{SessionID: "s", CostDelta: 0.5, MessageDelta: 1},
+			SessionChange{SessionID: "s", CostDelta: 0.75, MessageDelta: 3},
+		},
+		{
+			"flags stick",
+			SessionChange{SessionID: "s", New: true},
+			SessionChange{SessionID: "s", BecameActive: true, MessageDelta: 4},
+			SessionChange{SessionID: "s", New: true, BecameActive: true, MessageDelta: 4},
+		},
+		{
+			"later without flags",
+			SessionChange{SessionID: "s", BecameActive: true},
+			SessionChange{SessionID: "s", CostDelta: 1},
+			SessionChange{SessionID: "s", BecameActive: true, CostDelta: 1},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.first.Merge(tt.later); got != tt.want {
+			t.Errorf("%s: %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Error kinds.
+const (
+	ErrorRateLimit  = "rate_limit"
+	ErrorOverloaded = "overloaded"
+	ErrorAborted    = "aborted"
+	ErrorTool       = "tool"
+	ErrorAPI        = "api"
+)
+
+// GetErrors returns the error events of every session, newest first.
+func (c *Client) GetErrors() []ErrorEvent {
+	var events []ErrorEvent
+	sessions, _ := c.loadSessions()
+	for _, s := range sessions {
+		events = append(events, s.Errors...)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At > events[j].At
+	})
+	return events
+}
+
+// errorFromEntry turns an error entry, an assistant message that stopped
+// with an error or was aborted, or a failed tool result into an ErrorEvent.
+// model is the model in use at that point of the transcript.
+func errorFromEntry(entry *transcriptEntry, model string) (ErrorEvent, bool) {
+	if entry.Type == "error" {
+		return ErrorEvent{
+			Kind:    classifyError(entry.Error),
+			Model:   model,
+			Message: firstLine(entry.Error),
+			At:      entryTime(entry),
+		}, true
+	}
+
+	msg := entry.Message
+	if entry.Type != "message" || msg == nil {
+		return ErrorEvent{}, false
+	}
+	switch {
+	case msg.Role == "assistant" && msg.StopReason == "error":
+		text := msg.ErrorMessage
+		if text == "" {
+			text = "request failed"
+		}
+		return ErrorEvent{
+			Kind:    classifyError(text),
+			Model:   model,
+			Message: firstLine(text),
+			At:      entryTime(entry),
+		}, true
+	case msg.Role == "assistant" && msg.StopReason == "aborted":
+		text := msg.ErrorMessage
+		if text == "" {
+			text = "turn aborted"
+		}
+		return ErrorEvent{
+			Kind:    ErrorAborted,
+			Model:   model,
+			Message: firstLine(text),
+			At:      entryTime(entry),
+		}, true
+	case msg.Role == "toolResult" && msg.IsError:
+		text := firstLine(msg.text())
+		if msg.ToolName != "" {
+			text = strings.TrimSuffix(msg.ToolName+": "+text, ": ")
+		}
+		return ErrorEvent{
+			Kind:    ErrorTool,
+			Model:   model,
+			Message: text,
+			At:      entryTime(entry),
+		}, true
+	}
+	return ErrorEvent{}, false
+}
+
+// classifyError picks an error kind from a provider error message.
+func classifyError(text string) string {
+	lower := strings.ToLower(text)
+	switch {
+	case strings.Contains(lower, "429") || strings.Contains(lower, "rate limit") || strings.Contains(lower, "rate_limit"):
+		return ErrorRateLimit
+	case strings.Contains(lower, "529") || strings.Contains(lower, "overloaded"):
+		return ErrorOverloaded
+	case strings.Contains(lower, "abort"):
+		return ErrorAborted
+	}
+	return ErrorAPI
+}
+
+// entryTime returns the entry's time in Unix milliseconds, preferring the
+// message timestamp over the entry's RFC 3339 one.
+func entryTime(entry *transcriptEntry) int64 {
+	if entry.Message != nil && entry.Message.Timestamp > 0 {
+		return entry.Message.Timestamp
+	}
+	if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+		return t.UnixMilli()
+	}
+	return 0
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	if r := []rune(s); len(r) > 200 {
+		s = string(r[:199]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"math"
+	"time"
+)
+
+// forecastHistoryDays is how many complete days of spend the forecast
+// learns from.
+const forecastHistoryDays = 28
+
+// forecastZ widens the band to roughly an 80% interval.
+const forecastZ = 1.28
+
+// spendSeries is spend for one key (overall, a kind or a model).
+type spendSeries struct {
+	daily []float64 // complete days, oldest first
+	today float64
+	week  float64
+	month float64
+}
+
+// GetForecast projects spend to the end of the day, week (Monday to
+// Sunday) and month from the mean and spread of recent daily spend. The
+// band assumes days are independent. Breakdowns by kind and model are
+// month-end projections. With no complete day of history, today's run
+// rate is used and the band is ±50%. It reads the same agents and starts
+// the day at the same midnight as GetDashboard, so EndOfDay.Actual is the
+// dashboard's TodayCost.
+func (c *Client) GetForecast() Forecast {
+	now := c.Now()
+	today := alignBucket(now, BucketDay)
+	historyStart := today.AddDate(0, 0, -forecastHistoryDays)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+
+	earliest := historyStart
+	if monthStart.Before(earliest) {
+		earliest = monthStart
+	}
+
+	overall := &spendSeries{daily: make([]float64, forecastHistoryDays)}
+	byKind := make(map[string]*spendSeries)
+	byModel := make(map[string]*spendSeries)
+	firstDay := forecastHistoryDays // index of the first day with any spend
+	series := func(m map[string]*spendSeries, key string) *spendSeries {
+		s, ok := m[key]
+		if !ok {
+			s = &spendSeries{daily: make([]float64, forecastHistoryDays)}
+			m[key] = s
+		}
+		return s
+	}
+
+	c.scanMessages(ActivityFilter{}, func(m scannedMessage) {
+		if m.Cost == 0 || m.At.Before(earliest) || m.At.After(now) {
+			return
+		}
+		targets := []*spendSeries{overall, series(byKind, m.Kind)}
+		if m.Model != "" {
+			targets = append(targets, series(byModel, m.Model))
+		}
+		day := -1
+		if !m.At.Before(historyStart) && m.At.Before(today) {
+			day = daysBetween(historyStart, alignBucket(m.At, BucketDay))
+			if day < firstDay {
+				firstDay = day
+			}
+		}
+		for _, s := range targets {
+			if day >= 0 {
+				s.daily[day] += m.Cost
+			}
+			if !m.At.Before(today) {
+				s.today += m.Cost
+			}
+			if !m.At.Before(weekStart) {
+				s.week += m.Cost
+			}
+			if !m.At.Before(monthStart) {
+				s.month += m.Cost
+			}
+		}
+	})
+
+	remaining := func(end time.Time) float64 { return end.Sub(now).Hours() / 24 }
+	dayLeft := remaining(today.AddDate(0, 0, 1))
+	weekLeft := remaining(weekStart.AddDate(0, 0, 7))
+	monthLeft := remaining(monthStart.AddDate(0, 1, 0))
+	elapsed := math.Max(now.Sub(today).Hours()/24, 1.0/24)
+
+	history := forecastHistoryDays - firstDay
+	rate := func(s *spendSeries) (mean, stddev float64) {
+		if history == 0 {
+			mean = s.today / elapsed
+			return mean, mean / 2
+		}
+		return meanStdDev(s.daily[firstDay:])
+	}
+
+	mean, stddev := rate(overall)
+	f := Forecast{
+		GeneratedAt:   now.UnixMilli(),
+		HistoryDays:   history,
+		DailyMean:     mean,
+		DailyStdDev:   stddev,
+		EndOfDay:      project(overall.today, dayLeft, mean, stddev),
+		EndOfWeek:     project(overall.week, weekLeft, mean, stddev),
+		EndOfMonth:    project(overall.month, monthLeft, mean, stddev),
+		ByKind:        make(map[string]Projection, len(byKind)),
+		ByModel:       make(map[string]Projection, len(byModel)),
+		DailyBudget:   c.DailyBudget,
+		MonthlyBudget: c.MonthlyBudget,
+	}
+	for key, s := range byKind {
+		mean, stddev := rate(s)
+		f.ByKind[key] = project(s.month, monthLeft, mean, stddev)
+	}
+	for key, s := range byModel {
+		mean, stddev := rate(s)
+		f.ByModel[key] = project(s.month, monthLeft, mean, stddev)
+	}
+	f.OverDailyBudget = c.DailyBudget > 0 && f.EndOfDay.Expected > c.DailyBudget
+	f.OverBudget = c.MonthlyBudget > 0 && f.EndOfMonth.Expected > c.MonthlyBudget
+	return f
+}
+
+// project extends actual spend by days more days at mean per day. The band
+// grows with the square root of the days left and never drops below what
+// has already been spent.
+func project(actual, days, mean, stddev float64) Projection {
+	expected := actual + mean*days
+	half := forecastZ * stddev * math.Sqrt(days)
+	return Projection{
+		Actual:   actual,
+		Expected: expected,
+		Low:      math.Max(actual, expected-half),
+		High:     expected + half,
+	}
+}
+
+func meanStdDev(values []float64) (mean, stddev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stddev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(values)))
+}
+
+// daysBetween counts calendar days from a to b, both local midnights.
+func daysBetween(a, b time.Time) int {
+	n := 0
+	for d := a; d.Before(b); d = d.AddDate(0, 0, 1) {
+		n++
+	}
+	return n
+}
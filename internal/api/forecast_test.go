@@ -0,0 +1,101 @@
+package api
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name                   string
+		actual, days, mean, sd float64
+		expected, low, high    float64
+	}{
+		{"no spread", 2, 0.5, 4, 0, 4, 4, 4},
+		{"band", 1, 4, 2, 1, 9, 9 - 2*forecastZ, 9 + 2*forecastZ},
+		{"low clamped to actual", 5, 1, 1, 10, 6, 5, 6 + 10*forecastZ},
+		{"no time left", 3, 0, 2, 1, 3, 3, 3},
+	}
+	for _, tt := range tests {
+		p := project(tt.actual, tt.days, tt.mean, tt.sd)
+		if p.Actual != tt.actual || !near(p.Expected, tt.expected) || !near(p.Low, tt.low) || !near(p.High, tt.high) {
+			t.Errorf("%s: got %+v, want expected %v low %v high %v", tt.name, p, tt.expected, tt.low, tt.high)
+		}
+	}
+}
+
+func TestMeanStdDev(t *testing.T) {
+	tests := []struct {
+		in       []float64
+		mean, sd float64
+	}{
+		{[]float64{3}, 3, 0},
+		{[]float64{1, 1, 1}, 1, 0},
+		{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 2},
+	}
+	for _, tt := range tests {
+		mean, sd := meanStdDev(tt.in)
+		if !near(mean, tt.mean) || !near(sd, tt.sd) {
+			t.Errorf("meanStdDev(%v) = %v, %v; want %v, %v", tt.in, mean, sd, tt.mean, tt.sd)
+		}
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, ny) }
+	tests := []struct {
+		a, b time.Time
+		want int
+	}{
+		{day(3, 1), day(3, 1), 0},
+		{day(3, 1), day(3, 2), 1},
+		{day(3, 1), day(3, 15), 14}, // across the spring DST change
+		{day(2, 20), day(3, 2), 10},
+		{day(3, 2), day(3, 1), 0},
+	}
+	for _, tt := range tests {
+		if got := daysBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("daysBetween(%s, %s) = %d, want %d", tt.a.Format("Jan 2"), tt.b.Format("Jan 2"), got, tt.want)
+		}
+	}
+}
+
+func TestGetForecast(t *testing.T) {
+	c := newTestClient(t)
+	now := c.Now()
+	today := alignBucket(now, BucketDay)
+	spentToday := testMessage{at: today.Add(now.Sub(today) / 2), cost: 0.5}
+	var history []testMessage
+	for d := 1; d <= 7; d++ {
+		history = append(history, testMessage{at: today.AddDate(0, 0, -d).Add(12 * time.Hour), cost: 1})
+	}
+	writeTranscript(t, c, "main", "today", spentToday)
+	writeTranscript(t, c, "ops", "history", history...)
+
+	f := c.GetForecast()
+	if f.HistoryDays != 7 || !near(f.DailyMean, 1) || !near(f.DailyStdDev, 0) {
+		t.Fatalf("history %d days, mean %v, stddev %v; want 7 days of $1", f.HistoryDays, f.DailyMean, f.DailyStdDev)
+	}
+	dayLeft := today.AddDate(0, 0, 1).Sub(now).Hours() / 24
+	if f.EndOfDay.Actual != 0.5 || math.Abs(f.EndOfDay.Expected-(0.5+dayLeft)) > 0.01 {
+		t.Errorf("EndOfDay = %+v, want actual 0.5 and expected about %v", f.EndOfDay, 0.5+dayLeft)
+	}
+	if d := c.GetDashboard(); d.TodayCost != f.EndOfDay.Actual {
+		t.Errorf("dashboard TodayCost %v, forecast EndOfDay.Actual %v", d.TodayCost, f.EndOfDay.Actual)
+	}
+
+	// Scoped to one agent, the forecast only sees today's spend and falls
+	// back to today's run rate with a ±50% band.
+	c.Agents = []string{"main"}
+	f = c.GetForecast()
+	if f.HistoryDays != 0 || f.EndOfDay.Actual != 0.5 || !near(f.DailyStdDev, f.DailyMean/2) {
+		t.Errorf("Agents = [main]: %+v", f)
+	}
+}
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetHeatmap aggregates messages and cost over the last lookback into a
+// weekday × hour matrix in the client's time zone. Rows are indexed by time.Weekday
+// (Sunday is 0) and columns by hour of day.
+func (c *Client) GetHeatmap(lookback time.Duration, filter ActivityFilter) (*Heatmap, error) {
+	if lookback <= 0 {
+		return nil, fmt.Errorf("lookback must be positive, got %s", lookback)
+	}
+	to := c.Now()
+	buckets, err := c.GetActivity(to.Add(-lookback), to, BucketHour, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	hm := &Heatmap{From: buckets[0].Start.UnixMilli(), To: to.UnixMilli()}
+	for _, b := range buckets {
+		day, hour := b.Start.Weekday(), b.Start.Hour()
+		hm.Messages[day][hour] += b.Messages
+		hm.Cost[day][hour] += b.Cost
+		if hm.Messages[day][hour] > hm.MaxMessages {
+			hm.MaxMessages = hm.Messages[day][hour]
+		}
+		if hm.Cost[day][hour] > hm.MaxCost {
+			hm.MaxCost = hm.Cost[day][hour]
+		}
+	}
+	return hm, nil
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// latencySamples are raw measurements from one or more transcripts.
+type latencySamples struct {
+	turn []float64 // ms from user message or tool result to assistant reply
+	tool []float64 // ms from tool call to its result
+	tps  []float64 // output tokens per second of turn latency
+}
+
+// modelSamples groups samples by the model that produced them.
+type modelSamples map[string]*latencySamples
+
+func (m modelSamples) get(model string) *latencySamples {
+	ls, ok := m[model]
+	if !ok {
+		ls = &latencySamples{}
+		m[model] = ls
+	}
+	return ls
+}
+
+func (m modelSamples) merged() *latencySamples {
+	all := &latencySamples{}
+	for _, ls := range m {
+		all.add(ls)
+	}
+	return all
+}
+
+func (ls *latencySamples) add(other *latencySamples) {
+	ls.turn = append(ls.turn, other.turn...)
+	ls.tool = append(ls.tool, other.tool...)
+	ls.tps = append(ls.tps, other.tps...)
+}
+
+// stats returns nil when there is nothing to report.
+func (ls *latencySamples) stats(key, name string) *LatencyStats {
+	if len(ls.turn) == 0 && len(ls.tool) == 0 {
+		return nil
+	}
+	return &LatencyStats{
+		Key:          key,
+		Name:         name,
+		TurnLatency:  percentiles(ls.turn),
+		ToolTime:     percentiles(ls.tool),
+		TokensPerSec: percentiles(ls.tps),
+	}
+}
+
+// collectLatency measures turn latency, tool execution time and output
+// throughput from a message stream. Messages without a model are
+// attributed to fallbackModel.
+func collectLatency(stream []streamMessage, fallbackModel string) modelSamples {
+	samples := make(modelSamples)
+	type pendingCall struct {
+		at    time.Time
+		model string
+	}
+	calls := make(map[string]pendingCall)
+	var waitingSince time.Time
+
+	for _, m := range stream {
+		model := m.Model
+		if model == "" {
+			model = fallbackModel
+		}
+		switch m.Role {
+		case "user":
+			waitingSince = m.Time
+		case "toolResult":
+			waitingSince = m.Time
+			if call, ok := calls[m.ToolCallID]; ok {
+				if d := m.Time.Sub(call.at); d >= 0 {
+					samples.get(call.model).tool = append(samples.get(call.model).tool, float64(d.Milliseconds()))
+				}
+				delete(calls, m.ToolCallID)
+			}
+		case "assistant":
+			ls := samples.get(model)
+			if !waitingSince.IsZero() {
+				if d := m.Time.Sub(waitingSince); d > 0 {
+					ls.turn = append(ls.turn, float64(d.Milliseconds()))
+					if m.OutputTokens > 0 {
+						ls.tps = append(ls.tps, float64(m.OutputTokens)/d.Seconds())
+					}
+				}
+				waitingSince = time.Time{}
+			}
+			for _, call := range m.ToolCalls {
+				if call.ID != "" {
+					calls[call.ID] = pendingCall{at: m.Time, model: model}
+				}
+			}
+		}
+	}
+	return samples
+}
+
+// GetLatency returns latency and throughput percentiles per model and per
+// session. Models are sorted by name, sessions by most recently updated.
+func (c *Client) GetLatency() LatencyReport {
+	var report LatencyReport
+	byModel := make(modelSamples)
+	sessions, _ := c.loadSessions()
+	for _, s := range sessions {
+		for model, ls := range s.latency {
+			byModel.get(model).add(ls)
+		}
+		if s.Latency != nil {
+			report.Sessions = append(report.Sessions, *s.Latency)
+		}
+	}
+	for model, ls := range byModel {
+		if st := ls.stats(model, model); st != nil {
+			report.Models = append(report.Models, *st)
+		}
+	}
+	sort.Slice(report.Models, func(i, j int) bool {
+		return report.Models[i].Key < report.Models[j].Key
+	})
+	return report
+}
+
+// percentiles uses the nearest-rank method.
+func percentiles(values []float64) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+	return Percentiles{
+		Count: len(sorted),
+		P50:   rank(50),
+		P95:   rank(95),
+		P99:   rank(99),
+	}
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionQuery is a parsed session filter such as
+// "deploy kind:cron cost>0.5 model:opus updated<2h tag:prod". Bare words
+// match the name, model or ID; field terms compare one attribute. Every
+// part must match, and a leading '-' negates a part.
+//
+// Fields:
+//
+//	kind:K     main, cron or subagent
+//	agent:A    OpenClaw agent A
+//	model:M    model contains M
+//	name:N     name contains N
+//	id:I       session ID starts with I
+//	tag:T      has tag T
+//	is:S       active, idle, pinned, hidden, stuck, anomaly, errors or context
+//	cost, today, messages, tokens, errors, compactions   numeric, e.g. cost>=1.5
+//	context    context utilization in percent, e.g. context>80
+//	updated    time since the last update, e.g. updated<2h or updated>3d
+type SessionQuery struct {
+	raw   string
+	terms []queryTerm
+}
+
+type queryTerm struct {
+	field  string // "" for a bare word
+	op     string // ":", "=", "<", "<=", ">" or ">="
+	value  string // lower-cased
+	num    float64
+	negate bool
+}
+
+// queryFields lists the supported fields and whether they compare numbers
+// (or durations, for updated).
+var queryFields = map[string]bool{
+	"kind": false, "agent": false, "model": false, "name": false, "id": false, "tag": false, "is": false,
+	"cost": true, "today": true, "messages": true, "tokens": true, "errors": true,
+	"compactions": true, "context": true, "updated": true,
+}
+
+var queryStates = map[string]bool{
+	"active": true, "idle": true, "pinned": true, "hidden": true,
+	"stuck": true, "anomaly": true, "errors": true, "context": true,
+}
+
+// ParseSessionQuery parses a filter string. An empty string matches every
+// session.
+func ParseSessionQuery(s string) (SessionQuery, error) {
+	q := SessionQuery{raw: strings.TrimSpace(s)}
+	for _, word := range splitQuery(q.raw) {
+		t, err := parseQueryTerm(word)
+		if err != nil {
+			return SessionQuery{}, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// String returns the query as typed.
+func (q SessionQuery) String() string { return q.raw }
+
+// IsZero reports whether the query matches everything.
+func (q SessionQuery) IsZero() bool { return len(q.terms) == 0 }
+
+// Match reports whether s satisfies every part of the query at time now.
+func (q SessionQuery) Match(s Session, now time.Time) bool {
+	for _, t := range q.terms {
+		if t.match(s, now) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// splitQuery splits on spaces, keeping double-quoted runs together and
+// dropping the quotes.
+func splitQuery(s string) []string {
+	var words []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if cur.Len() > 0 {
+				words = append(words, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		words = append(words, cur.String())
+	}
+	return words
+}
+
+func parseQueryTerm(word string) (queryTerm, error) {
+	var t queryTerm
+	if len(word) > 1 && word[0] == '-' {
+		t.negate = true
+		word = word[1:]
+	}
+	i := strings.IndexAny(word, ":=<>")
+	if i <= 0 {
+		t.value = strings.ToLower(word)
+		return t, nil
+	}
+	t.field = strings.ToLower(word[:i])
+	numeric, ok := queryFields[t.field]
+	if !ok {
+		return t, fmt.Errorf("unknown filter field %q", t.field)
+	}
+	rest := word[i:]
+	for _, op := range []string{"<=", ">=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(rest, op) {
+			t.op = op
+			break
+		}
+	}
+	t.value = strings.ToLower(rest[len(t.op):])
+	if t.value == "" {
+		return t, fmt.Errorf("%s%s needs a value", t.field, t.op)
+	}
+	if !numeric {
+		if t.op != ":" && t.op != "=" {
+			return t, fmt.Errorf("%s only supports %s:value", t.field, t.field)
+		}
+		if t.field == "is" && !queryStates[t.value] {
+			return t, fmt.Errorf("unknown state is:%s", t.value)
+		}
+		return t, nil
+	}
+
+	switch t.field {
+	case "updated":
+		d, err := parseAge(t.value)
+		if err != nil {
+			return t, fmt.Errorf("updated: %w", err)
+		}
+		t.num = d.Seconds()
+	default:
+		f, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSuffix(t.value, "%"), "$"), 64)
+		if err != nil {
+			return t, fmt.Errorf("%s: %q is not a number", t.field, t.value)
+		}
+		t.num = f
+	}
+	return t, nil
+}
+
+// parseAge extends time.ParseDuration with a "d" (day) unit.
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+func (t queryTerm) match(s Session, now time.Time) bool {
+	contains := func(v string) bool { return strings.Contains(strings.ToLower(v), t.value) }
+	switch t.field {
+	case "":
+		return contains(s.Name) || contains(s.OriginalName) || contains(s.Model) || contains(s.SessionID)
+	case "kind":
+		return s.Kind == t.value
+	case "agent":
+		return strings.ToLower(s.Agent) == t.value
+	case "model":
+		return contains(s.Model)
+	case "name":
+		return contains(s.Name) || contains(s.OriginalName)
+	case "id":
+		return strings.HasPrefix(strings.ToLower(s.SessionID), t.value)
+	case "tag":
+		return HasTag(s.Tags, t.value)
+	case "is":
+		switch t.value {
+		case "active":
+			return s.IsActive
+		case "idle":
+			return !s.IsActive
+		case "pinned":
+			return s.Pinned
+		case "hidden":
+			return s.Hidden
+		case "stuck":
+			return s.Stuck
+		case "anomaly":
+			return len(s.Anomalies) > 0
+		case "errors":
+			return s.ErrorCount > 0
+		case "context":
+			return s.ContextWarning
+		}
+		return false
+	case "cost":
+		return t.compare(s.TotalCost)
+	case "today":
+		return t.compare(s.TodayCost)
+	case "messages":
+		return t.compare(float64(s.MessageCount))
+	case "tokens":
+		return t.compare(float64(s.TotalTokens))
+	case "errors":
+		return t.compare(float64(s.ErrorCount))
+	case "compactions":
+		return t.compare(float64(s.CompactionCount))
+	case "context":
+		return t.compare(s.ContextUtilization * 100)
+	case "updated":
+		return t.compare(now.Sub(time.UnixMilli(s.UpdatedAt)).Seconds())
+	}
+	return false
+}
+
+func (t queryTerm) compare(v float64) bool {
+	switch t.op {
+	case "<":
+		return v < t.num
+	case "<=":
+		return v <= t.num
+	case ">":
+		return v > t.num
+	case ">=":
+		return v >= t.num
+	}
+	return v == t.num
+}
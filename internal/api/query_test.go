@@ -0,0 +1,121 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSessionQuery(t *testing.T) {
+	tests := []struct {
+		in      string
+		terms   int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"   ", 0, false},
+		{"deploy", 1, false},
+		{"deploy kind:cron cost>0.5 model:opus updated<2h tag:prod", 6, false},
+		{`"two words" -is:hidden`, 2, false},
+		{"cost>=$1.5 context>80%", 2, false},
+		{"updated>3d", 1, false},
+		{"-", 1, false},
+		{"color:red", 0, true},
+		{"cost>", 0, true},
+		{"cost>abc", 0, true},
+		{"kind>cron", 0, true},
+		{"is:sleeping", 0, true},
+		{"updated<soon", 0, true},
+	}
+	for _, tt := range tests {
+		q, err := ParseSessionQuery(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSessionQuery(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && len(q.terms) != tt.terms {
+			t.Errorf("ParseSessionQuery(%q) has %d terms, want %d", tt.in, len(q.terms), tt.terms)
+		}
+	}
+}
+
+func TestSessionQueryMatch(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := Session{
+		SessionID:          "a1b2c3d4-0000",
+		Name:               "Deploy prod",
+		Kind:               "cron",
+		Agent:              "ops",
+		Model:              "anthropic/claude-opus-4-5",
+		TotalCost:          1.5,
+		TodayCost:          0.25,
+		MessageCount:       40,
+		UpdatedAt:          now.Add(-90 * time.Minute).UnixMilli(),
+		Tags:               []string{"prod"},
+		Pinned:             true,
+		ContextUtilization: 0.85,
+		ContextWarning:     true,
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"deploy", true},
+		{"DEPLOY", true},
+		{"staging", false},
+		{"-deploy", false},
+		{`"deploy prod"`, true},
+		{"kind:cron", true},
+		{"kind:main", false},
+		{"agent:ops", true},
+		{"model:opus", true},
+		{"id:a1b2", true},
+		{"id:b2", false},
+		{"tag:prod", true},
+		{"tag:dev", false},
+		{"is:pinned", true},
+		{"is:active", false},
+		{"is:context", true},
+		{"cost>1", true},
+		{"cost>=1.5", true},
+		{"cost<1.5", false},
+		{"today=0.25", true},
+		{"messages<=40", true},
+		{"context>80", true},
+		{"updated<2h", true},
+		{"updated<1h", false},
+		{"updated>1d", false},
+		{"deploy kind:cron -is:hidden", true},
+		{"deploy kind:main", false},
+	}
+	for _, tt := range tests {
+		q, err := ParseSessionQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseSessionQuery(%q): %v", tt.query, err)
+		}
+		if got := q.Match(s, now); got != tt.want {
+			t.Errorf("%q.Match = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90s", 90 * time.Second, false},
+		{"2h", 2 * time.Hour, false},
+		{"3d", 72 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseAge(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseAge(%q) = %v, %v; want %v, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CopyFormats are the ways SessionText can render a session.
+var CopyFormats = []string{"id", "path", "summary", "markdown"}
+
+// TranscriptPath returns the path of a session's transcript file, in the
+// directory of whichever agent it belongs to.
+func (c *Client) TranscriptPath(sessionID string) string {
+	agents, _ := c.agentNames()
+	for _, agent := range agents {
+		path := filepath.Join(c.sessionsDir(agent), sessionID+".jsonl")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(c.sessionsDir("main"), sessionID+".jsonl")
+}
+
+// SessionText renders a session for pasting elsewhere:
+//
+//	id        the session ID
+//	path      the transcript path
+//	summary   one line with the name, kind, model, messages and cost
+//	markdown  the detail card as a Markdown list
+func (c *Client) SessionText(s Session, format string) (string, error) {
+	switch format {
+	case "id":
+		return s.SessionID, nil
+	case "path":
+		return c.TranscriptPath(s.SessionID), nil
+	case "summary":
+		return c.sessionSummary(s), nil
+	case "markdown":
+		return c.sessionMarkdown(s), nil
+	}
+	return "", fmt.Errorf("unknown copy format %q (want %s)", format, strings.Join(CopyFormats, ", "))
+}
+
+func (c *Client) sessionSummary(s Session) string {
+	parts := []string{s.Name, s.Kind, s.Model,
+		fmt.Sprintf("%d messages", s.MessageCount),
+		fmt.Sprintf("$%.2f today", s.TodayCost),
+		fmt.Sprintf("$%.2f total", s.TotalCost),
+	}
+	if s.ErrorCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d errors", s.ErrorCount))
+	}
+	parts = append(parts, "updated "+c.formatTime(s.UpdatedAt), s.SessionID)
+	return strings.Join(parts, " · ")
+}
+
+func (c *Client) sessionMarkdown(s Session) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s\n\n", s.Name)
+	field := func(label, format string, args ...any) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", label, fmt.Sprintf(format, args...))
+	}
+
+	status := "inactive"
+	switch {
+	case s.Stuck:
+		status = fmt.Sprintf("stuck (%s, last progress %s)", s.StuckReason, c.formatTime(s.LastProgressAt))
+	case s.IsActive:
+		status = "active"
+	}
+	field("Status", "%s", status)
+	field("Kind", "%s", s.Kind)
+	if s.Agent != "" {
+		field("Agent", "%s", s.Agent)
+	}
+	field("Model", "`%s`", s.Model)
+	field("Messages", "%d", s.MessageCount)
+	field("Tokens", "%d", s.TotalTokens)
+	field("Today", "$%.4f", s.TodayCost)
+	field("Total", "$%.4f", s.TotalCost)
+	field("Updated", "%s", c.formatTime(s.UpdatedAt))
+	field("Session", "`%s`", s.SessionID)
+	if s.ParentID != "" {
+		field("Parent", "`%s`", s.ParentID)
+	}
+	field("Transcript", "`%s`", c.TranscriptPath(s.SessionID))
+	if s.OriginalName != "" {
+		field("OpenClaw name", "%s", s.OriginalName)
+	}
+	if len(s.Tags) > 0 {
+		field("Tags", "%s", strings.Join(s.Tags, ", "))
+	}
+	if s.Pinned {
+		field("Pinned", "yes")
+	}
+	if s.Note != "" {
+		field("Note", "%s", s.Note)
+	}
+	if s.ErrorCount > 0 {
+		last := ""
+		if s.LastError != nil {
+			last = fmt.Sprintf(" (last: %s at %s: %s)", s.LastError.Kind, c.formatTime(s.LastError.At), s.LastError.Message)
+		}
+		field("Errors", "%d%s", s.ErrorCount, last)
+	}
+	if s.ContextLimit > 0 {
+		field("Context", "%d / %d tokens (%.0f%%), %d compactions", s.ContextTokens, s.ContextLimit, s.ContextUtilization*100, s.CompactionCount)
+	}
+	if lat := s.Latency; lat != nil && lat.TurnLatency.Count > 0 {
+		field("Latency", "p50 %.0fms, p95 %.0fms, p99 %.0fms", lat.TurnLatency.P50, lat.TurnLatency.P95, lat.TurnLatency.P99)
+	}
+	if len(s.Anomalies) > 0 {
+		b.WriteString("\n**Anomalies**\n\n")
+		for _, a := range s.Anomalies {
+			fmt.Fprintf(&b, "- %s: %s (%s)\n", a.Kind, a.Message, c.formatTime(a.At))
+		}
+	}
+	return b.String()
+}
+
+// formatTime formats a Unix millisecond time in the client's Location.
+func (c *Client) formatTime(ms int64) string {
+	return time.UnixMilli(ms).In(c.location()).Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// detectStuck marks sub-agents whose transcript ends waiting on something:
+// an unanswered tool call, or a user message or tool result with no
+// assistant reply. They are stuck once the wait exceeds StuckAfter, or
+// straight away if their parent session has ended.
+func (c *Client) detectStuck(sessions []Session, streams map[string][]streamMessage) {
+	for i := range sessions {
+		s := &sessions[i]
+		if s.Kind != "subagent" {
+			continue
+		}
+		stream := streams[s.SessionID]
+		if len(stream) == 0 {
+			continue
+		}
+		last := stream[len(stream)-1]
+		s.LastProgressAt = last.Time.UnixMilli()
+
+		waiting := pendingReason(last)
+		if waiting == "" {
+			continue
+		}
+
+		switch {
+		case s.ParentID != "" && parentEnded(streams[s.ParentID], stream[0].Time):
+			s.Stuck = true
+			s.StuckReason = waiting + "; parent session ended"
+		case c.StuckAfter > 0 && time.Since(last.Time) > c.StuckAfter:
+			s.Stuck = true
+			s.StuckReason = waiting
+		}
+	}
+}
+
+// parentEnded reports whether a parent transcript finished after spawning a
+// sub-agent at spawned: its last message is a final assistant reply, sent
+// after the spawn. An idle parent may just be waiting on its sub-agents,
+// and a parent that was not read says nothing either way.
+func parentEnded(parent []streamMessage, spawned time.Time) bool {
+	if len(parent) == 0 {
+		return false
+	}
+	last := parent[len(parent)-1]
+	return last.Role == "assistant" && len(last.ToolCalls) == 0 && last.Time.After(spawned)
+}
+
+// pendingReason describes what the last message of a transcript is waiting
+// for, or returns "" if the transcript ends with a finished reply.
+func pendingReason(last streamMessage) string {
+	switch last.Role {
+	case "assistant":
+		if len(last.ToolCalls) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("waiting on tool %s", last.ToolCalls[len(last.ToolCalls)-1].Name)
+	case "user":
+		return "user message has no reply"
+	case "toolResult":
+		return "tool result has no reply"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectStuck(t *testing.T) {
+	now := time.Now()
+	c := &Client{StuckAfter: 10 * time.Minute}
+	spawned := now.Add(-5 * time.Minute)
+	waiting := []streamMessage{
+		{Time: spawned, Role: "user"},
+		toolCall(now.Add(-time.Minute), "exec", `{}`),
+	}
+	longWaiting := []streamMessage{
+		{Time: now.Add(-time.Hour), Role: "user"},
+		toolCall(now.Add(-30*time.Minute), "exec", `{}`),
+	}
+	finished := []streamMessage{
+		{Time: spawned, Role: "user"},
+		{Time: now.Add(-time.Minute), Role: "assistant"},
+	}
+	parentWaiting := []streamMessage{toolCall(spawned.Add(-time.Second), "spawn", `{}`)}
+	parentDone := []streamMessage{
+		toolCall(spawned.Add(-time.Second), "spawn", `{}`),
+		{Time: spawned.Add(time.Minute), Role: "assistant"},
+	}
+	parentDoneBefore := []streamMessage{{Time: spawned.Add(-time.Minute), Role: "assistant"}}
+
+	tests := []struct {
+		name   string
+		child  []streamMessage
+		parent []streamMessage // nil: no parent session
+		stuck  bool
+		reason string
+	}{
+		{"finished", finished, parentDone, false, ""},
+		{"waiting past StuckAfter", longWaiting, parentWaiting, true, "waiting on tool exec"},
+		{"parent waiting on it", waiting, parentWaiting, false, ""},
+		{"parent not read", waiting, nil, false, ""},
+		{"parent replied after spawn", waiting, parentDone, true, "waiting on tool exec; parent session ended"},
+		{"parent replied before spawn", waiting, parentDoneBefore, false, ""},
+	}
+	for _, tt := range tests {
+		sessions := []Session{{SessionID: "child", Kind: "subagent", ParentID: "parent"}}
+		streams := map[string][]streamMessage{"child": tt.child}
+		if tt.parent != nil {
+			sessions = append(sessions, Session{SessionID: "parent", Kind: "main"})
+			streams["parent"] = tt.parent
+		}
+		c.detectStuck(sessions, streams)
+		s := sessions[0]
+		if s.Stuck != tt.stuck || s.StuckReason != tt.reason {
+			t.Errorf("%s: stuck %v %q, want %v %q", tt.name, s.Stuck, s.StuckReason, tt.stuck, tt.reason)
+		}
+		if want := tt.child[len(tt.child)-1].Time.UnixMilli(); s.LastProgressAt != want {
+			t.Errorf("%s: LastProgressAt %d, want %d", tt.name, s.LastProgressAt, want)
+		}
+	}
+}
+
+func TestPendingReason(t *testing.T) {
+	tests := []struct {
+		last streamMessage
+		want string
+	}{
+		{streamMessage{Role: "assistant"}, ""},
+		{toolCall(time.Time{}, "read", `{}`), "waiting on tool read"},
+		{streamMessage{Role: "user"}, "user message has no reply"},
+		{streamMessage{Role: "toolResult"}, "tool result has no reply"},
+		{streamMessage{Role: "system"}, ""},
+	}
+	for _, tt := range tests {
+		if got := pendingReason(tt.last); got != tt.want {
+			t.Errorf("pendingReason(%s) = %q, want %q", tt.last.Role, got, tt.want)
+		}
+	}
+}
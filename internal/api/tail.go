@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+)
+
+// Tail entry kinds.
+const (
+	TailUser       = "user"
+	TailAssistant  = "assistant"
+	TailToolCall   = "tool_call"
+	TailToolResult = "tool_result"
+	TailError      = "error"
+	TailCompaction = "compaction"
+)
+
+// maxTailText caps the text kept per tail entry.
+const maxTailText = 500
+
+// TailEntry is one event in a live session tail.
+type TailEntry struct {
+	At     int64   `json:"at"`
+	Kind   string  `json:"kind"`
+	Text   string  `json:"text,omitempty"` // message text, tool arguments, result or error
+	Tool   string  `json:"tool,omitempty"`
+	Model  string  `json:"model,omitempty"`
+	Cost   float64 `json:"cost,omitempty"`   // set on assistant entries
+	Failed bool    `json:"failed,omitempty"` // set on failed tool results
+}
+
+// Tailer follows one session transcript. Each Read returns only the
+// entries appended since the previous one.
+type Tailer struct {
+	path    string
+	file    os.FileInfo // the file offset is into
+	offset  int64
+	partial []byte // an unfinished last line, completed by a later write
+	model   string
+	full    bool // keep whole texts; see RenderTranscript
+}
+
+// NewTailer returns a Tailer for a session whose first Read returns the
+// whole transcript so far.
+func (c *Client) NewTailer(sessionID string) *Tailer {
+	return &Tailer{path: c.TranscriptPath(sessionID)}
+}
+
+// Read returns the entries appended since the last call. If the transcript
+// was truncated, or replaced by another file at the same path, it starts
+// over from the beginning and reports restarted so the caller can drop
+// what it has.
+func (t *Tailer) Read() (entries []TailEntry, restarted bool, err error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+	if t.file != nil && (!os.SameFile(t.file, info) || info.Size() < t.offset) {
+		t.offset, t.partial, t.model = 0, nil, ""
+		restarted = true
+	}
+	t.file = info
+	if info.Size() == t.offset {
+		return nil, restarted, nil
+	}
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return nil, restarted, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, restarted, err
+	}
+	t.offset += int64(len(data))
+
+	data = append(t.partial, data...)
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		t.partial = data
+		return nil, restarted, nil
+	}
+	t.partial = append([]byte(nil), data[end+1:]...)
+
+	for _, line := range bytes.Split(data[:end], []byte("\n")) {
+		entries = append(entries, t.parseLine(line)...)
+	}
+	return entries, restarted, nil
+}
+
+// flush returns the entries of an unfinished last line as if it were
+// complete, for a reader of the whole file, and forgets it.
+func (t *Tailer) flush() []TailEntry {
+	line := t.partial
+	t.partial = nil
+	return t.parseLine(line)
+}
+
+// parseLine converts one transcript line to tail entries, skipping blank
+// and malformed lines.
+func (t *Tailer) parseLine(line []byte) []TailEntry {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return nil
+	}
+	var entry transcriptEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil
+	}
+	return t.entries(&entry)
+}
+
+// entries converts one transcript entry to tail entries: an assistant
+// message yields its text and then one entry per tool call.
+func (t *Tailer) entries(entry *transcriptEntry) []TailEntry {
+	at := entryTime(entry)
+	if isCompaction(entry.Type) {
+		return []TailEntry{{At: at, Kind: TailCompaction, Text: "context compacted"}}
+	}
+	msg := entry.Message
+	if msg != nil && msg.Role == "assistant" && msg.Model != "" {
+		t.model = msg.Model
+	}
+	if ev, ok := errorFromEntry(entry, t.model); ok && ev.Kind != ErrorTool {
+		return []TailEntry{{At: at, Kind: TailError, Text: ev.Message, Model: t.model}}
+	}
+	if entry.Type != "message" || msg == nil {
+		return nil
+	}
+
+	switch msg.Role {
+	case "user":
+		return []TailEntry{{At: at, Kind: TailUser, Text: t.clip(msg.text())}}
+	case "assistant":
+		out := []TailEntry{{At: at, Kind: TailAssistant, Text: t.clip(msg.text()), Model: t.model}}
+		if msg.Usage != nil && msg.Usage.Cost != nil {
+			out[0].Cost = msg.Usage.Cost.Total
+		}
+		for _, call := range msg.toolCalls() {
+			out = append(out, TailEntry{At: at, Kind: TailToolCall, Tool: call.Name, Text: t.clip(compactJSON(call.Arguments))})
+		}
+		return out
+	case "toolResult":
+		return []TailEntry{{At: at, Kind: TailToolResult, Tool: msg.ToolName, Text: t.clip(msg.text()), Failed: msg.IsError}}
+	}
+	return nil
+}
+
+// compactJSON renders raw JSON on one line.
+func compactJSON(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// clip trims s and cuts it to maxTailText runes.
+func (t *Tailer) clip(s string) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > maxTailText && !t.full {
+		s = string(r[:maxTailText-1]) + "…"
+	}
+	return s
+}
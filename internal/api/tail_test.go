@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// userLine is a transcript line for a user message.
+func userLine(text string, at int64) string {
+	return fmt.Sprintf(`{"type":"message","message":{"role":"user","content":%q,"timestamp":%d}}`+"\n", text, at)
+}
+
+func TestTailerRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	write := func(flag int, data string) {
+		t.Helper()
+		f, err := os.OpenFile(path, flag|os.O_WRONLY|os.O_CREATE, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.WriteString(data); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+	appendLine := func(data string) { write(os.O_APPEND, data) }
+	rewrite := func(data string) { write(os.O_TRUNC, data) }
+	replace := func(data string) {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	long := userLine("one", 1) + userLine("two", 2) + userLine("three", 3)
+
+	tr := &Tailer{path: path}
+	steps := []struct {
+		name      string
+		change    func()
+		texts     []string
+		restarted bool
+	}{
+		{"first read", func() { rewrite(userLine("one", 1)) }, []string{"one"}, false},
+		{"unchanged", func() {}, nil, false},
+		{"appended", func() { appendLine(userLine("two", 2)) }, []string{"two"}, false},
+		{"partial line", func() { appendLine(`{"type":"message",`) }, nil, false},
+		{"line completed", func() { appendLine(`"message":{"role":"user","content":"three","timestamp":3}}` + "\n") }, []string{"three"}, false},
+		{"truncated", func() { rewrite(userLine("again", 4)) }, []string{"again"}, true},
+		{"replaced by a longer file", func() { replace(long) }, []string{"one", "two", "three"}, true},
+		{"appended after replace", func() { appendLine(userLine("four", 5)) }, []string{"four"}, false},
+	}
+	for _, step := range steps {
+		step.change()
+		entries, restarted, err := tr.Read()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var texts []string
+		for _, e := range entries {
+			texts = append(texts, e.Text)
+		}
+		if fmt.Sprint(texts) != fmt.Sprint(step.texts) || restarted != step.restarted {
+			t.Errorf("%s: got %q restarted %v, want %q restarted %v", step.name, texts, restarted, step.texts, step.restarted)
+		}
+	}
+}
+
+func TestTailerReadMissing(t *testing.T) {
+	tr := &Tailer{path: filepath.Join(t.TempDir(), "missing.jsonl")}
+	if _, _, err := tr.Read(); err == nil {
+		t.Error("no error for a missing transcript")
+	}
+}
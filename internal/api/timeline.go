@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxTimelineBuckets is the most buckets an automatically sized timeline
+// uses; the smallest bucket size that fits is chosen.
+const maxTimelineBuckets = 60
+
+// GetSessionTimeline returns one session's activity from its first to its
+// last message: message and cost buckets plus a marker per tool call.
+// A zero bucket picks the smallest supported size that keeps the timeline
+// within 60 buckets.
+func (c *Client) GetSessionTimeline(sessionID string, bucket time.Duration) (*SessionTimeline, error) {
+	path := c.TranscriptPath(sessionID)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	type message struct {
+		at   time.Time
+		cost float64
+	}
+	var messages []message
+	var tools []ToolMarker
+	calls := make(map[string]int) // tool call ID -> index in tools
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry transcriptEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		if entry.Type != "message" || entry.Message == nil || entry.Message.Timestamp <= 0 {
+			continue
+		}
+		msg := entry.Message
+		m := message{at: time.UnixMilli(msg.Timestamp).In(c.location())}
+		if msg.Usage != nil && msg.Usage.Cost != nil {
+			m.cost = msg.Usage.Cost.Total
+		}
+		messages = append(messages, m)
+
+		for _, call := range msg.toolCalls() {
+			calls[call.ID] = len(tools)
+			tools = append(tools, ToolMarker{At: msg.Timestamp, Name: call.Name})
+		}
+		if msg.ToolCallID != "" && msg.IsError {
+			if i, ok := calls[msg.ToolCallID]; ok {
+				tools[i].Failed = true
+			}
+		}
+	}
+
+	tl := &SessionTimeline{SessionID: sessionID, ToolCalls: tools}
+	if len(messages) == 0 {
+		return tl, nil
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].at.Before(messages[j].at) })
+	start, end := messages[0].at, messages[len(messages)-1].at
+	tl.Start = start.UnixMilli()
+	tl.End = end.UnixMilli()
+
+	if bucket == 0 {
+		bucket = timelineBucket(start, end)
+	}
+	switch bucket {
+	case BucketMinute, BucketFiveMinute, BucketHour, BucketDay:
+	default:
+		return nil, fmt.Errorf("unsupported bucket size %s", bucket)
+	}
+	tl.Bucket = shortDuration(bucket)
+
+	for t := alignBucket(start, bucket); !t.After(end); t = nextBucket(t, bucket) {
+		if len(tl.Buckets) == maxActivityBuckets {
+			return nil, fmt.Errorf("session too long for %s buckets", tl.Bucket)
+		}
+		tl.Buckets = append(tl.Buckets, ActivityBucket{Start: t})
+	}
+	for _, m := range messages {
+		idx := sort.Search(len(tl.Buckets), func(i int) bool {
+			return tl.Buckets[i].Start.After(m.at)
+		}) - 1
+		tl.Buckets[idx].Messages++
+		tl.Buckets[idx].Cost += m.cost
+	}
+	return tl, nil
+}
+
+// timelineBucket returns the smallest bucket size that covers start to end
+// in at most maxTimelineBuckets buckets.
+func timelineBucket(start, end time.Time) time.Duration {
+	for _, b := range []time.Duration{BucketMinute, BucketFiveMinute, BucketHour} {
+		if end.Sub(alignBucket(start, b)) < time.Duration(maxTimelineBuckets)*b {
+			return b
+		}
+	}
+	return BucketDay
+}
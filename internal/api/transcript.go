@@ -0,0 +1,106 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TranscriptFormats are the formats RenderTranscript accepts.
+var TranscriptFormats = []string{"markdown", "text"}
+
+// RenderTranscript renders a session's whole transcript for reading in a
+// pager or editor: user and assistant messages in full, tool calls with
+// their arguments, tool results, errors and compactions, in order.
+// format is "markdown" or "text".
+func (c *Client) RenderTranscript(s Session, format string) (string, error) {
+	if format != "markdown" && format != "text" {
+		return "", fmt.Errorf("unknown transcript format %q (want %s)", format, strings.Join(TranscriptFormats, ", "))
+	}
+	t := c.NewTailer(s.SessionID)
+	t.full = true
+	entries, _, err := t.Read()
+	if err != nil {
+		return "", err
+	}
+	// The agent may be midway through writing the last line.
+	entries = append(entries, t.flush()...)
+
+	var b strings.Builder
+	header := fmt.Sprintf("%s · %s · %d messages · $%.4f", s.SessionID, s.Model, s.MessageCount, s.TotalCost)
+	if format == "markdown" {
+		fmt.Fprintf(&b, "# %s\n\n%s\n", s.Name, header)
+	} else {
+		fmt.Fprintf(&b, "%s\n%s\n%s\n", s.Name, header, strings.Repeat("=", len([]rune(header))))
+	}
+	for _, e := range entries {
+		b.WriteString("\n")
+		if format == "markdown" {
+			c.markdownEntry(&b, e)
+		} else {
+			c.textEntry(&b, e)
+		}
+	}
+	return b.String(), nil
+}
+
+// entryTitle describes an entry: who or what, and when.
+func (c *Client) entryTitle(e TailEntry) string {
+	title := e.Kind
+	switch e.Kind {
+	case TailUser:
+		title = "User"
+	case TailAssistant:
+		title = "Assistant"
+		if e.Cost > 0 {
+			title += fmt.Sprintf(" ($%.4f)", e.Cost)
+		}
+	case TailToolCall:
+		title = "→ " + e.Tool
+	case TailToolResult:
+		title = "← " + e.Tool
+		if e.Failed {
+			title += " (failed)"
+		}
+	case TailError:
+		title = "Error"
+	case TailCompaction:
+		title = "Compaction"
+	}
+	return title + " · " + time.UnixMilli(e.At).In(c.location()).Format("Jan 2 15:04:05")
+}
+
+func (c *Client) markdownEntry(b *strings.Builder, e TailEntry) {
+	switch e.Kind {
+	case TailToolCall:
+		fmt.Fprintf(b, "### %s\n\n```json\n%s\n```\n", c.entryTitle(e), indentJSON(e.Text))
+	case TailToolResult:
+		fmt.Fprintf(b, "### %s\n\n```\n%s\n```\n", c.entryTitle(e), e.Text)
+	case TailError, TailCompaction:
+		fmt.Fprintf(b, "> **%s** %s\n", c.entryTitle(e), e.Text)
+	default:
+		fmt.Fprintf(b, "## %s\n\n%s\n", c.entryTitle(e), e.Text)
+	}
+}
+
+func (c *Client) textEntry(b *strings.Builder, e TailEntry) {
+	text := e.Text
+	if e.Kind == TailToolCall {
+		text = indentJSON(text)
+	}
+	fmt.Fprintf(b, "── %s\n", c.entryTitle(e))
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "  %s\n", line)
+	}
+}
+
+// indentJSON pretty-prints s if it is JSON.
+func indentJSON(s string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return s
+	}
+	return buf.String()
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderTranscript(t *testing.T) {
+	c := newTestClient(t)
+	dir := c.sessionsDir("main")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"terminated", userLine("first", 1) + userLine("second", 2), []string{"first", "second"}},
+		{"last line unterminated", userLine("first", 1) + strings.TrimSuffix(userLine("second", 2), "\n"), []string{"first", "second"}},
+		{"last line cut short", userLine("first", 1) + `{"type":"message","mess`, []string{"first"}},
+	}
+	for _, tt := range tests {
+		if err := os.WriteFile(filepath.Join(dir, "s.jsonl"), []byte(tt.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		for _, format := range TranscriptFormats {
+			out, err := c.RenderTranscript(Session{SessionID: "s", Name: "test"}, format)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			for _, text := range tt.want {
+				if !strings.Contains(out, text) {
+					t.Errorf("%s, %s: %q missing from\n%s", tt.name, format, text, out)
+				}
+			}
+		}
+	}
+	if _, err := c.RenderTranscript(Session{SessionID: "s"}, "html"); err == nil {
+		t.Error("no error for an unknown format")
+	}
+}
@@ -1,16 +1,81 @@
 package api
 
+import "time"
+
 // Session represents a monitored OpenClaw session.
 type Session struct {
 	SessionID    string  `json:"sessionId"`
 	Name         string  `json:"name"`
 	Kind         string  `json:"kind"`
+	Agent        string  `json:"agent"`
 	Model        string  `json:"model"`
 	MessageCount int     `json:"messageCount"`
 	TotalCost    float64 `json:"totalCost"`
 	TodayCost    float64 `json:"todayCost"`
 	UpdatedAt    int64   `json:"updatedAt"`
 	IsActive     bool    `json:"isActive"`
+	ParentID     string  `json:"parentId,omitempty"`
+
+	// User annotations. OriginalName is the OpenClaw name when the user
+	// renamed the session.
+	OriginalName string   `json:"originalName,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Note         string   `json:"note,omitempty"`
+	Pinned       bool     `json:"pinned,omitempty"`
+	Hidden       bool     `json:"hidden,omitempty"`
+
+	Anomalies []Anomaly `json:"anomalies,omitempty"`
+
+	// Stuck is set on sub-agents that stopped making progress.
+	// LastProgressAt is the time of their last transcript message.
+	Stuck          bool   `json:"stuck,omitempty"`
+	StuckReason    string `json:"stuckReason,omitempty"`
+	LastProgressAt int64  `json:"lastProgressAt,omitempty"`
+
+	ErrorCount int          `json:"errorCount"`
+	LastError  *ErrorEvent  `json:"lastError,omitempty"`
+	Errors     []ErrorEvent `json:"-"`
+
+	Latency *LatencyStats `json:"latency,omitempty"`
+	latency modelSamples
+
+	// Context window usage. ContextTokens is the size of the most recent
+	// prompt, ContextUtilization its share of ContextLimit (0 if unknown).
+	TotalTokens        int            `json:"totalTokens"`
+	ContextTokens      int            `json:"contextTokens"`
+	ContextLimit       int            `json:"contextLimit"`
+	ContextUtilization float64        `json:"contextUtilization"`
+	ContextWarning     bool           `json:"contextWarning,omitempty"`
+	ContextHistory     []ContextPoint `json:"contextHistory,omitempty"`
+	CompactionCount    int            `json:"compactionCount"`
+	LastCompactionAt   int64          `json:"lastCompactionAt,omitempty"`
+}
+
+// ContextPoint is the context size at one assistant turn.
+type ContextPoint struct {
+	At          int64   `json:"at"`
+	Tokens      int     `json:"tokens"`
+	Utilization float64 `json:"utilization"`
+}
+
+// ErrorEvent is an API error, aborted turn or tool failure found in a
+// transcript.
+type ErrorEvent struct {
+	SessionID   string `json:"sessionId"`
+	SessionName string `json:"sessionName"`
+	Kind        string `json:"kind"`
+	Model       string `json:"model,omitempty"`
+	Message     string `json:"message"`
+	At          int64  `json:"at"`
+}
+
+// Anomaly is unusual behaviour spotted in a session's message stream.
+type Anomaly struct {
+	Kind     string  `json:"kind"`
+	Message  string  `json:"message"`
+	At       int64   `json:"at"`
+	Value    float64 `json:"value"`
+	Baseline float64 `json:"baseline"`
 }
 
 // DashboardData is the full dashboard response.
@@ -19,11 +84,93 @@ type DashboardData struct {
 	TotalCount int       `json:"totalCount"`
 	TotalCost  float64   `json:"totalCost"`
 	TodayCost  float64   `json:"todayCost"`
+	ErrorCount int       `json:"errorCount"`
+}
+
+// ActivityBucket represents activity in one time bucket starting at Start.
+type ActivityBucket struct {
+	Start    time.Time `json:"start"`
+	Messages int       `json:"messages"`
+	Cost     float64   `json:"cost"`
+}
+
+// Heatmap is activity folded into a week: Messages[day][hour] with days
+// indexed by time.Weekday. From and To are Unix milliseconds.
+type Heatmap struct {
+	From        int64          `json:"from"`
+	To          int64          `json:"to"`
+	Messages    [7][24]int     `json:"messages"`
+	Cost        [7][24]float64 `json:"cost"`
+	MaxMessages int            `json:"maxMessages"`
+	MaxCost     float64        `json:"maxCost"`
+}
+
+// Forecast projects spend to the end of the current day, week and month.
+type Forecast struct {
+	GeneratedAt     int64                 `json:"generatedAt"`
+	HistoryDays     int                   `json:"historyDays"` // complete days the rate is based on
+	DailyMean       float64               `json:"dailyMean"`
+	DailyStdDev     float64               `json:"dailyStdDev"`
+	EndOfDay        Projection            `json:"endOfDay"`
+	EndOfWeek       Projection            `json:"endOfWeek"`
+	EndOfMonth      Projection            `json:"endOfMonth"`
+	ByKind          map[string]Projection `json:"byKind"`  // month-end, by session kind
+	ByModel         map[string]Projection `json:"byModel"` // month-end, by model
+	DailyBudget     float64               `json:"dailyBudget,omitempty"`
+	MonthlyBudget   float64               `json:"monthlyBudget,omitempty"`
+	OverDailyBudget bool                  `json:"overDailyBudget,omitempty"`
+	OverBudget      bool                  `json:"overBudget,omitempty"` // month-end over MonthlyBudget
+}
+
+// Projection is spend so far in a period and where it is expected to end,
+// with a Low-High confidence band.
+type Projection struct {
+	Actual   float64 `json:"actual"`
+	Expected float64 `json:"expected"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+}
+
+// SessionTimeline is one session's activity between its first and last
+// message. Start and End are Unix milliseconds; Bucket is the bucket size
+// ("1m", "5m", "1h" or "24h").
+type SessionTimeline struct {
+	SessionID string           `json:"sessionId"`
+	Start     int64            `json:"start"`
+	End       int64            `json:"end"`
+	Bucket    string           `json:"bucket"`
+	Buckets   []ActivityBucket `json:"buckets"`
+	ToolCalls []ToolMarker     `json:"toolCalls"`
+}
+
+// ToolMarker is a tool call made at At. Failed is set when its result was
+// an error.
+type ToolMarker struct {
+	At     int64  `json:"at"`
+	Name   string `json:"name"`
+	Failed bool   `json:"failed,omitempty"`
+}
+
+// Percentiles summarises a set of samples.
+type Percentiles struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+}
+
+// LatencyStats holds latency and throughput percentiles for one model or
+// session. Times are in milliseconds.
+type LatencyStats struct {
+	Key          string      `json:"key"`
+	Name         string      `json:"name"`
+	TurnLatency  Percentiles `json:"turnLatency"`
+	ToolTime     Percentiles `json:"toolTime"`
+	TokensPerSec Percentiles `json:"tokensPerSec"`
 }
 
-// HourlyBucket represents activity in one hour.
-type HourlyBucket struct {
-	Hour     string  `json:"hour"`
-	Messages int     `json:"messages"`
-	Cost     float64 `json:"cost"`
+// LatencyReport is latency broken down per model and per session.
+type LatencyReport struct {
+	Models   []LatencyStats `json:"models"`
+	Sessions []LatencyStats `json:"sessions"`
 }
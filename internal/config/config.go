@@ -0,0 +1,320 @@
+// Package config loads Antenna's settings, shared by the GUI and the TUI.
+//
+// Settings come from, in increasing precedence: built-in defaults, the
+// config file, environment variables and command-line flags. The file is
+// JSON at $XDG_CONFIG_HOME/antenna/config.json (~/.config/antenna on Linux,
+// the platform config directory elsewhere) unless ANTENNA_CONFIG names
+// another path.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Caryyon/antenna/internal/api"
+)
+
+// Config holds every user-configurable setting.
+type Config struct {
+	// OpenclawDir is the OpenClaw data directory; AnnotationsFile is where
+	// Antenna keeps its own session annotations.
+	OpenclawDir     string `json:"openclawDir,omitempty"`
+	AnnotationsFile string `json:"annotationsFile,omitempty"`
+
+	// Agents limits which agents under openclawDir/agents are read, e.g.
+	// ["main", "ops"]; empty reads all of them.
+	Agents []string `json:"agents,omitempty"`
+
+	// Interval is the refresh polling interval.
+	Interval Duration `json:"interval,omitempty"`
+
+	// ActiveWindow is how recently a session must have been updated to be
+	// active; StuckAfter is how long a waiting sub-agent may go without
+	// progress before it is marked stuck.
+	ActiveWindow Duration `json:"activeWindow,omitempty"`
+	StuckAfter   Duration `json:"stuckAfter,omitempty"`
+
+	// ContextWarn is the context utilization (0-1) that flags a session;
+	// 0 turns the warning off.
+	ContextWarn float64 `json:"contextWarn,omitempty"`
+
+	// Timezone is an IANA name such as "Europe/Berlin"; empty means local.
+	Timezone string `json:"timezone,omitempty"`
+
+	Budget Budget `json:"budget,omitempty"`
+
+	// Theme names the TUI color theme or a theme file; "auto" follows the
+	// terminal background and NO_COLOR.
+	Theme string `json:"theme,omitempty"`
+
+	// Keys rebinds TUI actions to a key or a list of keys, e.g.
+	// {"errors": "E", "down": ["j", "ctrl+n"]}.
+	Keys map[string]KeyList `json:"keys,omitempty"`
+
+	// Columns picks the TUI session row columns after the name, e.g.
+	// ["model", "tokens", "age"]; empty chooses by terminal width.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// Budget holds spend limits in dollars; 0 means none.
+type Budget struct {
+	Daily   float64 `json:"daily,omitempty"`
+	Monthly float64 `json:"monthly,omitempty"`
+}
+
+// Duration is a time.Duration that reads from JSON as "5s"-style strings
+// or as a number of seconds.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case float64:
+		*d = Duration(v * float64(time.Second))
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// KeyList is a list of keys that reads from JSON as a single key or an
+// array.
+type KeyList []string
+
+func (k *KeyList) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*k = KeyList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("keys must be a string or a list of strings")
+	}
+	*k = many
+	return nil
+}
+
+// Default returns the built-in settings.
+func Default() Config {
+	return Config{
+		OpenclawDir:     api.DefaultOpenclawDir(),
+		AnnotationsFile: api.DefaultAnnotationsPath(),
+		Interval:        Duration(5 * time.Second),
+		ActiveWindow:    Duration(30 * time.Minute),
+		StuckAfter:      Duration(10 * time.Minute),
+		ContextWarn:     0.8,
+		Theme:           "auto",
+	}
+}
+
+// DefaultPath returns the config file location: ANTENNA_CONFIG if set,
+// otherwise antenna/config.json in the user config directory.
+func DefaultPath() string {
+	if p := os.Getenv("ANTENNA_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "antenna", "config.json")
+}
+
+// Loader reads the config file and notices when it changes.
+type Loader struct {
+	Path string
+
+	// Override, if set, is applied last on every load. Binaries use it to
+	// give command-line flags the final say.
+	Override func(*Config)
+
+	modTime time.Time
+	size    int64
+}
+
+// Load builds the config from defaults, the file, the environment and
+// Override. A missing file is not an error. On a malformed file the
+// returned config is still usable, without the file's settings.
+func (l *Loader) Load() (Config, error) {
+	cfg := Default()
+	var fileErr error
+	if l.Path != "" {
+		info, err := os.Stat(l.Path)
+		switch {
+		case err == nil:
+			l.modTime, l.size = info.ModTime(), info.Size()
+			fileErr = readFile(l.Path, &cfg)
+		case errors.Is(err, os.ErrNotExist):
+			l.modTime, l.size = time.Time{}, 0
+		default:
+			fileErr = err
+		}
+	}
+	envErr := applyEnv(&cfg)
+	if l.Override != nil {
+		l.Override(&cfg)
+	}
+	cfg.OpenclawDir = expandHome(cfg.OpenclawDir)
+	cfg.AnnotationsFile = expandHome(cfg.AnnotationsFile)
+	return cfg, errors.Join(fileErr, envErr, cfg.validate())
+}
+
+// Changed reports whether the file was created, modified or removed since
+// the last Load.
+func (l *Loader) Changed() bool {
+	if l.Path == "" {
+		return false
+	}
+	info, err := os.Stat(l.Path)
+	if err != nil {
+		return !l.modTime.IsZero()
+	}
+	return !info.ModTime().Equal(l.modTime) || info.Size() != l.size
+}
+
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// Decode over a copy so a malformed file leaves cfg untouched.
+	next := *cfg
+	if err := json.Unmarshal(data, &next); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	*cfg = next
+	return nil
+}
+
+// applyEnv overrides cfg from ANTENNA_* and OPENCLAW_DIR variables.
+func applyEnv(cfg *Config) error {
+	var errs []error
+	if v := os.Getenv("OPENCLAW_DIR"); v != "" {
+		cfg.OpenclawDir = v
+	}
+	if v := os.Getenv("ANTENNA_ANNOTATIONS"); v != "" {
+		cfg.AnnotationsFile = v
+	}
+	if v := os.Getenv("ANTENNA_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Interval = Duration(d)
+		} else {
+			errs = append(errs, fmt.Errorf("ANTENNA_INTERVAL: %w", err))
+		}
+	}
+	if v := os.Getenv("ANTENNA_CONTEXT_WARN"); v != "" {
+		if f, err := ParsePercent(v); err == nil {
+			cfg.ContextWarn = f
+		} else {
+			errs = append(errs, fmt.Errorf("ANTENNA_CONTEXT_WARN: %w", err))
+		}
+	}
+	if v := os.Getenv("ANTENNA_BUDGET"); v != "" {
+		if f, err := ParseDollars(v); err == nil {
+			cfg.Budget.Monthly = f
+		} else {
+			errs = append(errs, fmt.Errorf("ANTENNA_BUDGET: %w", err))
+		}
+	}
+	if v := os.Getenv("ANTENNA_TIMEZONE"); v != "" {
+		cfg.Timezone = v
+	}
+	if v := os.Getenv("ANTENNA_THEME"); v != "" {
+		cfg.Theme = v
+	}
+	return errors.Join(errs...)
+}
+
+// validate rejects settings that cannot be used, resetting them to their
+// defaults so the config stays usable.
+func (cfg *Config) validate() error {
+	def := Default()
+	var errs []error
+	if cfg.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("interval must be positive"))
+		cfg.Interval = def.Interval
+	}
+	if cfg.ContextWarn < 0 || cfg.ContextWarn > 1 {
+		errs = append(errs, fmt.Errorf("contextWarn must be between 0 and 1"))
+		cfg.ContextWarn = def.ContextWarn
+	}
+	if _, err := cfg.Location(); err != nil {
+		errs = append(errs, err)
+		cfg.Timezone = ""
+	}
+	return errors.Join(errs...)
+}
+
+// Location resolves Timezone; empty means time.Local.
+func (cfg Config) Location() (*time.Location, error) {
+	if cfg.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("timezone %q: %w", cfg.Timezone, err)
+	}
+	return loc, nil
+}
+
+// NewClient returns an api.Client set up from cfg.
+func (cfg Config) NewClient() *api.Client {
+	c := api.NewClient(cfg.OpenclawDir)
+	c.AnnotationsPath = cfg.AnnotationsFile
+	c.Agents = cfg.Agents
+	c.ActiveWindow = time.Duration(cfg.ActiveWindow)
+	c.StuckAfter = time.Duration(cfg.StuckAfter)
+	c.ContextWarn = cfg.ContextWarn
+	c.DailyBudget = cfg.Budget.Daily
+	c.MonthlyBudget = cfg.Budget.Monthly
+	if loc, err := cfg.Location(); err == nil {
+		c.Location = loc
+	}
+	return c
+}
+
+// ParsePercent accepts "80", "80%" or "0.8" and returns 0.8.
+func ParsePercent(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0, err
+	}
+	if f > 1 {
+		f /= 100
+	}
+	return f, nil
+}
+
+// ParseDollars accepts "50" or "$50".
+func ParseDollars(s string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(s), "$"), 64)
+}
+
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
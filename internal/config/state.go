@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State is what the TUI remembers between runs. Unlike Config it is
+// written by Antenna, not the user.
+type State struct {
+	Filter      string   `json:"filter,omitempty"`
+	ShowHidden  bool     `json:"showHidden,omitempty"`
+	Sort        string   `json:"sort,omitempty"`
+	SortReverse bool     `json:"sortReverse,omitempty"`
+	Columns     []string `json:"columns,omitempty"`
+	Recent      []string `json:"recent,omitempty"` // command palette lines, newest first
+}
+
+// DefaultStatePath returns antenna/tui.json under $XDG_STATE_HOME,
+// defaulting to ~/.local/state. It returns "" if neither can be found.
+func DefaultStatePath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "antenna", "tui.json")
+}
+
+// LoadState reads the state file. A missing or malformed file yields the
+// zero State.
+func LoadState(path string) State {
+	var st State
+	if path == "" {
+		return st
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return st
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return State{}
+	}
+	return st
+}
+
+// SaveState writes the state file atomically.
+func SaveState(path string, st State) error {
+	if path == "" {
+		return fmt.Errorf("no state file")
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}